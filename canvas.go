@@ -0,0 +1,149 @@
+package fppclient
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// ModelCanvas is a frame buffer for an overlay model, draw on it with the
+// image/draw package then Flush it to FPP in a single request.
+type ModelCanvas struct {
+	client Client
+	model  Model
+	img    *image.RGBA
+	dirty  image.Rectangle
+	cc     *ColorCorrection
+
+	// sent is the last frame flushed, so changes that were undone before
+	// the flush don't cause a request.
+	sent []int
+}
+
+func (c Client) NewModelCanvas(model Model) *ModelCanvas {
+	return &ModelCanvas{
+		client: c,
+		model:  model,
+		img:    image.NewRGBA(image.Rect(0, 0, model.Width, model.Height)),
+		cc:     c.ColorCorrectionFor(model.Name),
+	}
+}
+
+// SetColorCorrection replaces the colour correction the client would apply
+// to this model, nil disables it.
+func (mc *ModelCanvas) SetColorCorrection(cc *ColorCorrection) {
+	mc.cc = cc
+}
+
+func (mc *ModelCanvas) Model() Model {
+	return mc.model
+}
+
+func (mc *ModelCanvas) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+func (mc *ModelCanvas) Bounds() image.Rectangle {
+	return mc.img.Bounds()
+}
+
+func (mc *ModelCanvas) At(x, y int) color.Color {
+	return mc.img.At(x, y)
+}
+
+// Set only marks the pixel dirty if it actually changed.
+func (mc *ModelCanvas) Set(x, y int, c color.Color) {
+	p := image.Point{X: x, Y: y}
+	if !p.In(mc.img.Rect) {
+		return
+	}
+
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	if mc.img.RGBAAt(x, y) == rgba {
+		return
+	}
+
+	mc.img.SetRGBA(x, y, rgba)
+	mc.dirty = mc.dirty.Union(image.Rectangle{Min: p, Max: p.Add(image.Point{X: 1, Y: 1})})
+}
+
+// Dirty returns the area changed since the last flush, it's empty if
+// nothing has changed.
+func (mc *ModelCanvas) Dirty() image.Rectangle {
+	return mc.dirty
+}
+
+// MarkDirty forces the next Flush to send the frame.
+func (mc *ModelCanvas) MarkDirty() {
+	mc.dirty = mc.img.Rect
+	mc.sent = nil
+}
+
+// Pixels returns the canvas as a flat slice of RGB values, row by row.
+func (mc *ModelCanvas) Pixels() []int {
+	b := mc.img.Bounds()
+	data := make([]int, 0, b.Dx()*b.Dy()*3)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := mc.img.RGBAAt(x, y)
+			data = append(data, int(c.R), int(c.G), int(c.B))
+		}
+	}
+
+	return data
+}
+
+// Flush sends the whole frame to FPP if it differs from the last one sent,
+// nothing is sent while the canvas is clean.
+func (mc *ModelCanvas) Flush(ctx context.Context) error {
+	if mc.dirty.Empty() {
+		return nil
+	}
+
+	data := mc.cc.ApplyData(mc.Pixels())
+	if !equalInts(data, mc.sent) {
+		if err := mc.client.setOverlaysModelData(ctx, mc.model.Name, data); err != nil {
+			return err
+		}
+
+		mc.sent = data
+	}
+
+	mc.dirty = image.Rectangle{}
+
+	return nil
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetOverlaysModelData replaces the model's pixels, data is RGB values row by
+// row. It's sent run length encoded when that is smaller.
+func (c Client) SetOverlaysModelData(ctx context.Context, name string, data []int) error {
+	return c.setOverlaysModelData(ctx, name, c.ColorCorrectionFor(name).ApplyData(data))
+}
+
+func (c Client) setOverlaysModelData(ctx context.Context, name string, data []int) error {
+	if err := c.checkOverlaysModelLock(ctx, name); err != nil {
+		return fmt.Errorf("unable to set data on model %q: %w", name, err)
+	}
+
+	if err := c.overlays().SetModelData(ctx, name, data); err != nil {
+		return fmt.Errorf("unable to set data on model %q: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package fppclient_test
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestModelCanvas(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 4, Height: 2})
+
+	puts := func() int {
+		var n int
+		for _, req := range srv.Requests() {
+			if req == "PUT /api/overlays/model/Matrix/data" {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	lastWrite := func() fpptest.DataWrite {
+		w, ok := srv.LastDataWrite("Matrix")
+		require.True(t, ok)
+		return w
+	}
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	canvas := c.NewModelCanvas(fppclient.Model{Name: "Matrix", Width: 4, Height: 2})
+	var _ draw.Image = canvas
+
+	require.NoError(t, canvas.Flush(context.TODO()))
+	require.Zero(t, puts())
+
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(color.RGBA{10, 20, 30, 255}), image.Point{}, draw.Src)
+	require.Equal(t, canvas.Bounds(), canvas.Dirty())
+	require.NoError(t, canvas.Flush(context.TODO()))
+	require.True(t, canvas.Dirty().Empty())
+	require.Equal(t, fpptest.DataWrite{RLE: true, Data: []int{8, 10, 20, 30}}, lastWrite())
+
+	data, _ := srv.ModelData("Matrix")
+	require.Equal(t, []int{
+		10, 20, 30, 10, 20, 30, 10, 20, 30, 10, 20, 30,
+		10, 20, 30, 10, 20, 30, 10, 20, 30, 10, 20, 30,
+	}, data)
+
+	// Setting a pixel to its current colour isn't a change.
+	canvas.Set(0, 0, color.RGBA{10, 20, 30, 255})
+	require.True(t, canvas.Dirty().Empty())
+
+	canvas.Set(1, 1, color.RGBA{255, 0, 0, 255})
+	require.Equal(t, image.Rect(1, 1, 2, 2), canvas.Dirty())
+	require.NoError(t, canvas.Flush(context.TODO()))
+
+	secondWrite := fpptest.DataWrite{RLE: true, Data: []int{5, 10, 20, 30, 1, 255, 0, 0, 2, 10, 20, 30}}
+	require.Equal(t, secondWrite, lastWrite())
+
+	data, _ = srv.ModelData("Matrix")
+	require.Equal(t, []int{
+		10, 20, 30, 10, 20, 30, 10, 20, 30, 10, 20, 30,
+		10, 20, 30, 255, 0, 0, 10, 20, 30, 10, 20, 30,
+	}, data)
+
+	// A change that is undone before the flush sends nothing.
+	canvas.Set(2, 0, color.RGBA{0, 255, 0, 255})
+	canvas.Set(2, 0, color.RGBA{10, 20, 30, 255})
+	require.False(t, canvas.Dirty().Empty())
+	require.NoError(t, canvas.Flush(context.TODO()))
+	require.True(t, canvas.Dirty().Empty())
+	require.Equal(t, 2, puts())
+
+	canvas.MarkDirty()
+	require.NoError(t, canvas.Flush(context.TODO()))
+	require.Equal(t, 3, puts())
+	require.Equal(t, secondWrite, lastWrite())
+
+	// Raw data is sent when run length encoding wouldn't be smaller.
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			canvas.Set(x, y, color.RGBA{uint8(x), uint8(y), 1, 255})
+		}
+	}
+
+	require.NoError(t, canvas.Flush(context.TODO()))
+	require.Equal(t, fpptest.DataWrite{Data: []int{
+		0, 0, 1, 1, 0, 1, 2, 0, 1, 3, 0, 1,
+		0, 1, 1, 1, 1, 1, 2, 1, 1, 3, 1, 1,
+	}}, lastWrite())
+}
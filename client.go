@@ -11,6 +11,17 @@ import (
 type Client struct {
 	baseURL    *url.URL
 	httpClient *http.Client
+
+	commandCache     *commandCache
+	overlayLockCheck bool
+
+	colorCorrection      *ColorCorrection
+	modelColorCorrection map[string]*ColorCorrection
+
+	overlayBackend OverlayBackend
+	shmDir         string
+
+	decodeHook func(DecodeWarning)
 }
 
 func New(baseURL string, args ...newArg) (*Client, error) {
@@ -34,6 +45,11 @@ func New(baseURL string, args ...newArg) (*Client, error) {
 		}
 	}
 
+	// Shared memory can't do everything, give it a way to fall back to HTTP.
+	if c.shmDir != "" {
+		c.overlayBackend = NewShmOverlayBackend(c.shmDir, httpOverlayBackend{c: c})
+	}
+
 	return &c, nil
 }
 
@@ -69,3 +85,12 @@ func WithHTTPClient(httpClient *http.Client) newArg {
 		c.httpClient = httpClient
 	}
 }
+
+// WithOverlayLockCheck makes overlay writes check the model isn't locked
+// first, returning ErrModelLocked rather than having FPP ignore the write.
+// It costs an extra request per write.
+func WithOverlayLockCheck() newArg {
+	return func(c *Client) {
+		c.overlayLockCheck = true
+	}
+}
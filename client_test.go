@@ -1,71 +1,240 @@
 package fppclient_test
 
 import (
+	"bytes"
 	"context"
-	"net/http"
+	"io"
 	"testing"
 	"time"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/stretchr/testify/require"
 
 	"github.com/freman/fppclient"
-	"github.com/freman/fppclient/debug/dumptransport"
+	"github.com/freman/fppclient/fpptest"
 )
 
-func TestGetOverlaysModels(t *testing.T) {
-	c, err := fppclient.New("http://10.0.0.249")
+// newFakeFPP returns a fake FPP with a 4x2 panel called "LED Panels" and a
+// client to talk to it.
+func newFakeFPP(t *testing.T) (*fpptest.Server, *fppclient.Client) {
+	srv := fpptest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddModel(fppclient.Model{
+		Name:                "LED Panels",
+		Type:                "Channel",
+		ChannelCount:        24,
+		ChannelCountPerNode: 3,
+		StartChannel:        1,
+		StartCorner:         "TL",
+		Orientation:         "horizontal",
+		Width:               4,
+		Height:              2,
+		StringCount:         2,
+		StrandsPerString:    1,
+	})
+
+	c, err := fppclient.New(srv.URL)
 	require.NoError(t, err)
 
+	return srv, c
+}
+
+func TestGetOverlaysModels(t *testing.T) {
+	_, c := newFakeFPP(t)
+
 	models, err := c.GetOverlaysModels(context.TODO())
 	require.NoError(t, err)
-
-	spew.Dump(models)
+	require.Len(t, models, 1)
+	require.Equal(t, "LED Panels", models[0].Name)
 }
 
 func TestGetOverlaysModel(t *testing.T) {
-	c, err := fppclient.New("http://10.0.0.249")
-	require.NoError(t, err)
+	_, c := newFakeFPP(t)
 
 	model, err := c.GetOverlaysModel(context.TODO(), "LED Panels")
 	require.NoError(t, err)
+	require.Equal(t, 4, model.Width)
+	require.Equal(t, 2, model.Height)
 
-	spew.Dump(model)
+	_, err = c.GetOverlaysModel(context.TODO(), "Missing")
+	require.ErrorIs(t, err, fppclient.ErrNotFound)
 }
 
 func TestGetOverlaysModelData(t *testing.T) {
-	c, err := fppclient.New("http://10.0.0.249")
-	require.NoError(t, err)
+	_, c := newFakeFPP(t)
+
+	require.NoError(t, c.SetOverlaysModelPixel(context.TODO(), "LED Panels", 1, 0, 1, 2, 3))
 
 	modelData, err := c.GetOverlaysModelData(context.TODO(), "LED Panels", false)
 	require.NoError(t, err)
+	require.False(t, modelData.RLE)
+	require.Len(t, modelData.Data, 24)
 
 	modelDataRLE, err := c.GetOverlaysModelData(context.TODO(), "LED Panels", true)
 	require.NoError(t, err)
+	require.True(t, modelDataRLE.RLE)
 
-	_, _ = modelData, modelDataRLE
+	pixels, err := modelDataRLE.Pixels()
+	require.NoError(t, err)
+	require.Equal(t, modelData.Data, pixels)
 }
 
 func TestGetFonts(t *testing.T) {
-	c, err := fppclient.New("http://10.0.0.249")
-	require.NoError(t, err)
+	srv, c := newFakeFPP(t)
+	srv.SetFonts("FreeSans", "Helvetica")
 
 	fonts, err := c.GetOverlaysFonts(context.TODO())
 	require.NoError(t, err)
-
-	spew.Dump(fonts)
+	require.True(t, fonts.Contains("FreeSans"))
 }
 
 func TestFillModel(t *testing.T) {
-	c, err := fppclient.New("http://10.0.0.249", fppclient.WithHTTPClient(&http.Client{Timeout: 10 * time.Second, Transport: &dumptransport.DumpTransport{http.DefaultTransport}}))
+	srv, c := newFakeFPP(t)
+
+	require.NoError(t, c.FillOverlaysModel(context.TODO(), "LED Panels", 10, 20, 30))
+
+	data, _ := srv.ModelData("LED Panels")
+	require.Equal(t, []int{10, 20, 30}, data[21:])
+
+	require.NoError(t, c.ClearOverlaysModel(context.TODO(), "LED Panels"))
+
+	data, _ = srv.ModelData("LED Panels")
+	require.Equal(t, make([]int, 24), data)
+}
+
+func TestSetModelPixel(t *testing.T) {
+	srv, c := newFakeFPP(t)
+
+	require.NoError(t, c.SetOverlaysModelPixel(context.TODO(), "LED Panels", 0, 1, 90, 0, 0))
+
+	data, _ := srv.ModelData("LED Panels")
+	require.Equal(t, []int{90, 0, 0}, data[12:15])
+
+	require.ErrorIs(t, c.SetOverlaysModelPixel(context.TODO(), "LED Panels", 4, 0, 90, 0, 0), fppclient.ErrBadRequest)
+}
+
+func TestSetModelStateFake(t *testing.T) {
+	srv, c := newFakeFPP(t)
+
+	require.NoError(t, c.SetOverlaysModelState(context.TODO(), "LED Panels", fppclient.ModelStateTransparentRGB))
+
+	model, _ := srv.Model("LED Panels")
+	require.Equal(t, fppclient.ModelStateTransparentRGB, model.IsActive)
+}
+
+func TestPlaylistPlayback(t *testing.T) {
+	srv, c := newFakeFPP(t)
+	ctx := context.TODO()
+
+	require.NoError(t, c.CreatePlaylist(ctx, fppclient.Playlist{
+		Name: "Show",
+		MainPlaylist: fppclient.PlaylistEntries{
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "one.fseq"},
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "two.fseq"},
+		},
+	}))
+
+	playlists, err := c.GetPlaylists(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Show"}, playlists)
+
+	require.NoError(t, c.StartPlaylist(ctx, "Show", fppclient.StartPlaylistOptions{}))
+
+	status, err := c.GetFPPDStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "playing", status.StatusName)
+	require.Equal(t, "Show", status.CurrentPlaylist.Playlist)
+	require.Equal(t, "one.fseq", status.CurrentSequence)
+
+	require.NoError(t, c.NextPlaylistItem(ctx))
+	require.Equal(t, "two.fseq", srv.Status().CurrentSequence)
+
+	require.NoError(t, c.PausePlaylist(ctx))
+	require.Equal(t, "paused", srv.Status().StatusName)
+
+	require.NoError(t, c.ResumePlaylist(ctx))
+	require.NoError(t, c.StopPlaylist(ctx))
+	require.Equal(t, "idle", srv.Status().StatusName)
+
+	require.Len(t, srv.Commands(), 1)
+
+	require.ErrorIs(t, c.StartPlaylist(ctx, "Missing", fppclient.StartPlaylistOptions{}), fppclient.ErrNotFound)
+
+	require.NoError(t, c.RenamePlaylist(ctx, "Show", "Renamed"))
+
+	_, ok := srv.Playlist("Show")
+	require.False(t, ok)
+
+	playlist, err := c.GetPlaylist(ctx, "Renamed")
+	require.NoError(t, err)
+	require.Len(t, playlist.MainPlaylist, 2)
+}
+
+func TestFilesFake(t *testing.T) {
+	srv, c := newFakeFPP(t)
+	ctx := context.TODO()
+
+	require.NoError(t, c.UploadFile(ctx, "sequences", "show.fseq", bytes.NewReader([]byte("PSEQ"))))
+	require.NoError(t, c.CopyFile(ctx, "sequences", "show.fseq", "copy.fseq"))
+	require.NoError(t, c.RenameFile(ctx, "sequences", "copy.fseq", "moved.fseq"))
+
+	files, err := c.GetFiles(ctx, "sequences")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, "moved.fseq", files[0].Name)
+	require.Equal(t, 4, files[0].SizeBytes)
+
+	_, err = files[0].ModTime(time.Local)
+	require.NoError(t, err)
+
+	rc, err := c.DownloadFile(ctx, "sequences", "moved.fseq")
 	require.NoError(t, err)
 
-	require.NoError(t, c.FillOverlaysModel(context.TODO(), "LED Panels", 0, 0, 0))
+	b, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	require.Equal(t, "PSEQ", string(b))
+
+	require.NoError(t, c.DeleteFile(ctx, "sequences", "show.fseq"))
+
+	_, ok := srv.File("sequences", "show.fseq")
+	require.False(t, ok)
+
+	_, err = c.DownloadFile(ctx, "sequences", "show.fseq")
+	require.ErrorIs(t, err, fppclient.ErrNotFound)
+}
+
+func TestScheduleFake(t *testing.T) {
+	_, c := newFakeFPP(t)
+	ctx := context.TODO()
+
+	schedule, err := c.GetSchedule(ctx)
+	require.NoError(t, err)
+	require.Empty(t, schedule)
+
+	in := fppclient.ScheduleEntries{{Enabled: 1, Day: 7, Playlist: "Show", StartTime: "18:00:00", EndTime: "22:00:00"}}
+
+	schedule, err = c.PostSchedule(ctx, []fppclient.ScheduleEntries{in})
+	require.NoError(t, err)
+	require.Equal(t, []fppclient.ScheduleEntries{in}, schedule)
+
+	require.NoError(t, c.PostScheduleReload(ctx))
+
+	_, err = c.GetFPPDSchedule(ctx)
+	require.NoError(t, err)
 }
 
-func TestSetModelPixell(t *testing.T) {
-	c, err := fppclient.New("http://10.0.0.249", fppclient.WithHTTPClient(&http.Client{Timeout: 10 * time.Second, Transport: &dumptransport.DumpTransport{http.DefaultTransport}}))
+func TestGetChannelOutputs(t *testing.T) {
+	srv, c := newFakeFPP(t)
+
+	require.NoError(t, srv.SetConfig("channeloutputs.json", fppclient.ChannelOutputsObj{
+		ChannelOutputs: fppclient.ChannelOutputs{{Type: "LEDPanelMatrix", Gamma: "2.2", Brightness: 80, ColorOrder: "RGB"}},
+	}))
+
+	outputs, err := c.GetChannelOutputs(context.TODO())
 	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	require.Equal(t, 80, outputs[0].Brightness)
 
-	require.NoError(t, c.SetOverlaysModelPixel(context.TODO(), "LED Panels", 0, 0, 90, 0, 0))
+	require.ErrorIs(t, c.GetConfig(context.TODO(), "missing.json", &struct{}{}), fppclient.ErrNotFound)
 }
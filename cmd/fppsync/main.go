@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/freman/fppclient"
+)
+
+type globList []string
+
+func (g *globList) String() string {
+	return strings.Join(*g, ",")
+}
+
+func (g *globList) Set(v string) error {
+	*g = append(*g, v)
+	return nil
+}
+
+func main() {
+	var opts fppclient.SyncOptions
+
+	fppHost := flag.String("host", "10.0.0.249", "FPP host")
+	remoteDir := flag.String("dir", "sequences", "FPP media directory to sync to")
+	timeout := flag.Duration("timeout", 10*time.Minute, "timeout for each request")
+
+	flag.BoolVar(&opts.DryRun, "dry-run", false, "show what would be done without doing it")
+	flag.BoolVar(&opts.Delete, "delete", false, "delete files on the player that don't exist locally")
+	flag.BoolVar(&opts.Checksum, "checksum", false, "compare contents instead of size and mtime")
+	flag.IntVar(&opts.Concurrency, "concurrency", 2, "number of simultaneous transfers")
+	flag.Var((*globList)(&opts.Include), "include", "only sync files matching this glob, may be repeated")
+	flag.Var((*globList)(&opts.Exclude), "exclude", "skip files matching this glob, may be repeated")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <local path>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	c, err := fppclient.New("http://"+*fppHost, fppclient.WithHTTPClient(&http.Client{Timeout: *timeout}))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	result, err := c.SyncDir(ctx, flag.Arg(0), *remoteDir, opts)
+	for _, action := range result.Actions {
+		switch {
+		case opts.DryRun:
+			fmt.Println("would", action)
+		case action.Err != nil:
+			fmt.Println("failed", action, action.Err)
+		default:
+			fmt.Println(action)
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if len(result.Failed()) > 0 {
+		os.Exit(1)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
 	"os"
 	"os/signal"
 	"syscall"
@@ -58,7 +61,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := c.SetOverlaysModelState(context.TODO(), model.Name, 1); err != nil {
+	if err := c.SetOverlaysModelState(context.TODO(), model.Name, fppclient.ModelStateEnabled); err != nil {
 		panic(err)
 	}
 
@@ -70,54 +73,35 @@ func main() {
 		// if you shut it down while it's clearing it leaves lit pixels
 		time.Sleep(500 * time.Millisecond)
 
-		if err := c.SetOverlaysModelState(context.TODO(), model.Name, 0); err != nil {
+		if err := c.SetOverlaysModelState(context.TODO(), model.Name, fppclient.ModelStateDisabled); err != nil {
 			fmt.Println("Warning, failed to turn off the panel:", err.Error())
 
 		}
 	})
 
-	sequences := map[string][]int{
-		"red":    {100, 0, 0},
-		"green":  {0, 100, 0},
-		"blue":   {0, 0, 100},
-		"yellow": {80, 80, 0},
-		"purple": {80, 0, 80},
-		"cyan":   {0, 80, 80},
-		"white":  {60, 60, 60},
+	sequences := map[string]color.RGBA{
+		"red":    {100, 0, 0, 0xff},
+		"green":  {0, 100, 0, 0xff},
+		"blue":   {0, 0, 100, 0xff},
+		"yellow": {80, 80, 0, 0xff},
+		"purple": {80, 0, 80, 0xff},
+		"cyan":   {0, 80, 80, 0xff},
+		"white":  {60, 60, 60, 0xff},
 	}
 
-	type work struct {
-		x, y  int
-		color []int
-	}
-
-	chwork := make(chan work, 30)
-
-	for i := 0; i < 9; i++ {
-		go func() {
-			for job := range chwork {
-				if err := c.SetOverlaysModelPixel(context.TODO(), model.Name, job.x, job.y, job.color[0], job.color[1], job.color[2]); err != nil {
-					fmt.Printf("Warning, failed to configure pixel %d,%d: %v", job.x, job.y, err)
-				}
-			}
-		}()
-	}
+	canvas := c.NewModelCanvas(model)
+	area := image.Rect(panel.XOffset, panel.YOffset, panel.XOffset+outputPanel.PanelWidth, panel.YOffset+outputPanel.PanelHeight)
 
-	for name, color := range sequences {
+	for name, fill := range sequences {
 		fmt.Println("All", name)
-		for x, xend := panel.XOffset, panel.XOffset+outputPanel.PanelWidth; x < xend; x++ {
-			for y, yend := panel.YOffset, panel.YOffset+outputPanel.PanelHeight; y < yend; y++ {
-				chwork <- work{
-					x:     x,
-					y:     y,
-					color: color,
-				}
-			}
+
+		draw.Draw(canvas, area, image.NewUniform(fill), image.Point{}, draw.Src)
+		if err := canvas.Flush(context.TODO()); err != nil {
+			fmt.Println("Warning, failed to update panel:", err.Error())
 		}
+
 		time.Sleep(10 * time.Second)
 	}
-
-	close(chwork)
 }
 
 func promptForModel(c *fppclient.Client) (model fppclient.Model, err error) {
@@ -0,0 +1,157 @@
+package fppclient
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ColorCorrection is applied to colours before they're sent to an overlay
+// model, it's built once and is safe to share.
+type ColorCorrection struct {
+	order [3]int
+	table [3][256]uint8
+}
+
+type ColorCorrectionOptions struct {
+	// Gamma is the exponent applied to each channel, 0 or 1 for none.
+	Gamma float64
+
+	// Brightness is a percentage, 0 is treated as 100.
+	Brightness int
+
+	// WhiteBalance scales the red, green and blue channels, a zero
+	// multiplier is treated as 1.
+	WhiteBalance [3]float64
+
+	// ColorOrder is the order the channels are sent in, defaults to RGB.
+	ColorOrder string
+}
+
+func NewColorCorrection(opts ColorCorrectionOptions) (*ColorCorrection, error) {
+	var cc ColorCorrection
+
+	order := strings.ToUpper(opts.ColorOrder)
+	if order == "" {
+		order = "RGB"
+	}
+
+	if len(order) != 3 {
+		return nil, fmt.Errorf("invalid color order %q", opts.ColorOrder)
+	}
+
+	var seen [3]bool
+	for i, ch := range order {
+		idx := strings.IndexRune("RGB", ch)
+		if idx < 0 || seen[idx] {
+			return nil, fmt.Errorf("invalid color order %q", opts.ColorOrder)
+		}
+
+		seen[idx] = true
+		cc.order[i] = idx
+	}
+
+	gamma := opts.Gamma
+	if gamma <= 0 {
+		gamma = 1
+	}
+
+	brightness := float64(opts.Brightness) / 100
+	if opts.Brightness <= 0 {
+		brightness = 1
+	}
+
+	for ch := 0; ch < 3; ch++ {
+		wb := opts.WhiteBalance[ch]
+		if wb <= 0 {
+			wb = 1
+		}
+
+		for i := 0; i < 256; i++ {
+			v := math.Pow(float64(i)/255, gamma) * 255 * brightness * wb
+			cc.table[ch][i] = uint8(constrainToByte(int(math.Round(v))))
+		}
+	}
+
+	return &cc, nil
+}
+
+// ColorCorrectionFromOutput matches the gamma, brightness and colour order
+// configured on a channel output.
+func ColorCorrectionFromOutput(o ChannelOutput) (*ColorCorrection, error) {
+	opts := ColorCorrectionOptions{
+		Brightness: o.Brightness,
+		ColorOrder: o.ColorOrder,
+	}
+
+	if o.Gamma != "" {
+		gamma, err := strconv.ParseFloat(o.Gamma, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gamma %q: %w", o.Gamma, err)
+		}
+
+		opts.Gamma = gamma
+	}
+
+	return NewColorCorrection(opts)
+}
+
+// Apply corrects a single colour, a nil ColorCorrection passes it through.
+func (cc *ColorCorrection) Apply(r, g, b int) (int, int, int) {
+	in := [3]int{constrainToByte(r), constrainToByte(g), constrainToByte(b)}
+	if cc == nil {
+		return in[0], in[1], in[2]
+	}
+
+	var out [3]int
+	for i, src := range cc.order {
+		out[i] = int(cc.table[src][in[src]])
+	}
+
+	return out[0], out[1], out[2]
+}
+
+// ApplyData returns a corrected copy of RGB data.
+func (cc *ColorCorrection) ApplyData(data []int) []int {
+	if cc == nil {
+		return data
+	}
+
+	out := make([]int, len(data))
+	for i := 0; i+2 < len(data); i += 3 {
+		out[i], out[i+1], out[i+2] = cc.Apply(data[i], data[i+1], data[i+2])
+	}
+
+	return out
+}
+
+// ColorCorrectionFor returns the correction applied to overlay writes to the
+// named model, nil if there is none. Commands are sent as they are, so use
+// it to correct colours passed to effects or Overlay Model Fill.
+func (c Client) ColorCorrectionFor(name string) *ColorCorrection {
+	if cc, ok := c.modelColorCorrection[name]; ok {
+		return cc
+	}
+
+	return c.colorCorrection
+}
+
+// WithColorCorrection applies cc to every overlay write.
+func WithColorCorrection(cc *ColorCorrection) newArg {
+	return func(c *Client) {
+		c.colorCorrection = cc
+	}
+}
+
+// WithModelColorCorrection applies cc to overlay writes to the named model,
+// overriding WithColorCorrection.
+func WithModelColorCorrection(name string, cc *ColorCorrection) newArg {
+	return func(c *Client) {
+		if c.modelColorCorrection == nil {
+			c.modelColorCorrection = map[string]*ColorCorrection{}
+		}
+
+		c.modelColorCorrection[name] = cc
+	}
+}
@@ -0,0 +1,111 @@
+package fppclient_test
+
+import (
+	"context"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestColorCorrection(t *testing.T) {
+	var none *fppclient.ColorCorrection
+	r, g, b := none.Apply(300, 128, -5)
+	require.Equal(t, []int{255, 128, 0}, []int{r, g, b})
+
+	cc, err := fppclient.NewColorCorrection(fppclient.ColorCorrectionOptions{
+		Gamma:        2.2,
+		Brightness:   50,
+		WhiteBalance: [3]float64{1, 1, 0.5},
+		ColorOrder:   "grb",
+	})
+	require.NoError(t, err)
+
+	r, g, b = cc.Apply(255, 128, 255)
+	require.Equal(t, []int{28, 128, 64}, []int{r, g, b})
+
+	cc, err = fppclient.ColorCorrectionFromOutput(fppclient.ChannelOutput{Gamma: "1.0", ColorOrder: "BGR"})
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 2, 1}, cc.ApplyData([]int{1, 2, 3}))
+
+	_, err = fppclient.NewColorCorrection(fppclient.ColorCorrectionOptions{ColorOrder: "RRB"})
+	require.Error(t, err)
+}
+
+func TestColorCorrectionOnWrites(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 2, Height: 1})
+	srv.AddModel(fppclient.Model{Name: "Dim", Width: 1, Height: 1})
+
+	swap, err := fppclient.NewColorCorrection(fppclient.ColorCorrectionOptions{ColorOrder: "BGR"})
+	require.NoError(t, err)
+
+	half, err := fppclient.NewColorCorrection(fppclient.ColorCorrectionOptions{Brightness: 50})
+	require.NoError(t, err)
+
+	c, err := fppclient.New(srv.URL, fppclient.WithColorCorrection(swap), fppclient.WithModelColorCorrection("Dim", half))
+	require.NoError(t, err)
+
+	modelData := func(name string) []int {
+		data, ok := srv.ModelData(name)
+		require.True(t, ok)
+		return data
+	}
+
+	require.NoError(t, c.FillOverlaysModel(context.TODO(), "Matrix", 10, 20, 30))
+	require.Equal(t, []int{30, 20, 10, 30, 20, 10}, modelData("Matrix"))
+
+	require.NoError(t, c.SetOverlaysModelPixel(context.TODO(), "Dim", 0, 0, 10, 20, 30))
+	require.Equal(t, []int{5, 10, 15}, modelData("Dim"))
+
+	canvas := c.NewModelCanvas(fppclient.Model{Name: "Matrix", Width: 2, Height: 1})
+	canvas.Set(0, 0, color.RGBA{1, 2, 3, 255})
+	require.NoError(t, canvas.Flush(context.TODO()))
+	require.Equal(t, []int{3, 2, 1, 0, 0, 0}, modelData("Matrix"))
+
+	canvas.SetColorCorrection(nil)
+	canvas.Set(1, 0, color.RGBA{1, 2, 3, 255})
+	require.NoError(t, canvas.Flush(context.TODO()))
+	require.Equal(t, []int{1, 2, 3, 1, 2, 3}, modelData("Matrix"))
+}
+
+func TestColorCorrectionOnTextAndCommands(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Dim", Width: 8, Height: 1})
+	srv.SetFonts("FreeSans")
+
+	half, err := fppclient.NewColorCorrection(fppclient.ColorCorrectionOptions{Brightness: 50})
+	require.NoError(t, err)
+
+	c, err := fppclient.New(srv.URL, fppclient.WithModelColorCorrection("Dim", half))
+	require.NoError(t, err)
+
+	require.NoError(t, c.DisplayOverlaysText(context.TODO(), "Dim", fppclient.TextOptions{Message: "Hi", Color: color.RGBA{200, 100, 0, 255}}))
+	require.Equal(t, "#643200", srv.Texts("Dim")[0].Color)
+
+	// White by default, corrected too.
+	require.NoError(t, c.DisplayOverlaysText(context.TODO(), "Dim", fppclient.TextOptions{Message: "Hi"}))
+	require.Equal(t, "#808080", srv.Texts("Dim")[1].Color)
+
+	// Commands are sent as they are.
+	_, err = c.PostCommand(context.TODO(), fppclient.CommandOverlayModelFill("Dim", "Enabled", 200, 100, 0))
+	require.NoError(t, err)
+	require.NoError(t, c.StartOverlaysEffect(context.TODO(), "Dim", "Color Fade", fppclient.ModelStateEnabled, "#c86400"))
+
+	commands := srv.Commands()
+	require.Len(t, commands, 2)
+	require.Equal(t, "#c86400", commands[0].Args[2])
+	require.Equal(t, []string{"Dim", "Enabled", "Color Fade", "#c86400"}, commands[1].Args)
+
+	// Unless corrected by the caller.
+	r, g, b := c.ColorCorrectionFor("Dim").Apply(200, 100, 0)
+	require.Equal(t, []int{100, 50, 0}, []int{r, g, b})
+	require.Nil(t, c.ColorCorrectionFor("Matrix"))
+}
@@ -2,22 +2,194 @@ package fppclient
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"strconv"
-
-	"github.com/davecgh/go-spew/spew"
 )
 
+// CommandDefinition describes one of the commands FPP understands, as
+// reported by /api/commands.
+type CommandDefinition struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Args        []CommandArg `json:"args"`
+}
+
+// Arg returns the named argument definition.
+func (d CommandDefinition) Arg(name string) (CommandArg, bool) {
+	for _, a := range d.Args {
+		if a.Name == name {
+			return a, true
+		}
+	}
+
+	return CommandArg{}, false
+}
+
+type CommandArg struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Type           string    `json:"type"`
+	Optional       bool      `json:"optional"`
+	AllowBlanks    bool      `json:"allowBlanks"`
+	Default        Stringish `json:"default"`
+	Min            *int      `json:"min,omitempty"`
+	Max            *int      `json:"max,omitempty"`
+	Contents       []string  `json:"contents,omitempty"`
+	ContentListURL string    `json:"contentListUrl,omitempty"`
+}
+
+type CommandDefinitions []CommandDefinition
+
+// Get returns the named command definition.
+func (d CommandDefinitions) Get(name string) (CommandDefinition, bool) {
+	for _, def := range d {
+		if def.Name == name {
+			return def, true
+		}
+	}
+
+	return CommandDefinition{}, false
+}
+
+func (c Client) GetCommands(ctx context.Context) (commands CommandDefinitions, err error) {
+	if err = c.httpGet(ctx, "/api/commands", &commands); err != nil {
+		return nil, fmt.Errorf("unable to retrieve commands: %w", err)
+	}
+
+	return commands, err
+}
+
 type Command struct {
 	Command string   `json:"command"`
 	Args    []string `json:"args"`
 }
 
-func (c Client) PostCommand(ctx context.Context, cmd Command) {
-	res := map[string]interface{}{}
-	if err := c.httpPost(ctx, "/api/command", cmd, res); err != nil {
-		panic(err)
+// CommandResult is the response FPP gave to a command, depending on the
+// command this may be JSON or plain text.
+type CommandResult struct {
+	ContentType string
+	Body        []byte
+
+	// Status and Message are only populated when the response was JSON.
+	Status  string
+	Message string
+}
+
+// String returns the body of the response as text.
+func (r CommandResult) String() string {
+	return string(r.Body)
+}
+
+// Decode unmarshals a JSON response into v.
+func (r CommandResult) Decode(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+func (c Client) PostCommand(ctx context.Context, cmd Command) (CommandResult, error) {
+	const path = "/api/command"
+
+	if c.commandCache != nil {
+		defs, err := c.commandCache.get(ctx, c)
+		if err != nil {
+			return CommandResult{}, fmt.Errorf("unable to validate command %q: %w", cmd.Command, err)
+		}
+
+		if err := cmd.Validate(defs); err != nil {
+			return CommandResult{}, err
+		}
+	}
+
+	req, err := c.newJSONRequest(ctx, http.MethodPost, path, cmd)
+	if err != nil {
+		return CommandResult{}, fmt.Errorf("unable to run command %q: %w", cmd.Command, err)
+	}
+
+	var res CommandResult
+	if res.Body, res.ContentType, err = c.httpDoRaw(req); err != nil {
+		return CommandResult{}, fmt.Errorf("unable to run command %q: %w", cmd.Command, err)
+	}
+
+	status, ok := parseStatus(res.Body)
+	if !ok {
+		return res, nil
+	}
+
+	res.Status, res.Message = status.Status, status.Message
+
+	if err := status.check(http.MethodPost, path); err != nil {
+		return res, fmt.Errorf("unable to run command %q: %w", cmd.Command, err)
+	}
+
+	return res, nil
+}
+
+func CommandStartPlaylist(playlistName string, repeat, ifNotRunning bool) Command {
+	return Command{
+		Command: "Start Playlist",
+		Args: []string{
+			playlistName,
+			strconv.FormatBool(repeat),
+			strconv.FormatBool(ifNotRunning),
+		},
+	}
+}
+
+func CommandStartPlaylistAtItem(playlistName string, item int, repeat, ifNotRunning bool) Command {
+	return Command{
+		Command: "Start Playlist At Item",
+		Args: []string{
+			playlistName,
+			strconv.Itoa(item),
+			strconv.FormatBool(repeat),
+			strconv.FormatBool(ifNotRunning),
+		},
+	}
+}
+
+func CommandStartPlaylistAtRandomItem(playlistName string, repeat, ifNotRunning bool) Command {
+	return Command{
+		Command: "Start Playlist At Random Item",
+		Args: []string{
+			playlistName,
+			strconv.FormatBool(repeat),
+			strconv.FormatBool(ifNotRunning),
+		},
+	}
+}
+
+func CommandStopNow() Command {
+	return Command{Command: "Stop Now", Args: []string{}}
+}
+
+func CommandStopGracefully(afterLoop bool) Command {
+	return Command{
+		Command: "Stop Gracefully",
+		Args: []string{
+			strconv.FormatBool(afterLoop),
+		},
 	}
-	spew.Dump(res)
+}
+
+func CommandNextPlaylistItem() Command {
+	return Command{Command: "Next Playlist Item", Args: []string{}}
+}
+
+func CommandPrevPlaylistItem() Command {
+	return Command{Command: "Prev Playlist Item", Args: []string{}}
+}
+
+func CommandPausePlaylist() Command {
+	return Command{Command: "Pause Playlist", Args: []string{}}
+}
+
+func CommandResumePlaylist() Command {
+	return Command{Command: "Resume Playlist", Args: []string{}}
+}
+
+func CommandRestartPlaylistItem() Command {
+	return Command{Command: "Restart Playlist Item", Args: []string{}}
 }
 
 func CommandInsertPlaylistAfterCurrent(playlistName string, startIndex, endIndex int, ifNotRunning bool) Command {
@@ -31,3 +203,169 @@ func CommandInsertPlaylistAfterCurrent(playlistName string, startIndex, endIndex
 		},
 	}
 }
+
+func CommandInsertPlaylistImmediate(playlistName string, startIndex, endIndex int, ifNotRunning bool) Command {
+	return Command{
+		Command: "Insert Playlist Immediate",
+		Args: []string{
+			playlistName,
+			strconv.Itoa(startIndex),
+			strconv.Itoa(endIndex),
+			strconv.FormatBool(ifNotRunning),
+		},
+	}
+}
+
+func CommandVolumeSet(volume int) Command {
+	return Command{
+		Command: "Volume Set",
+		Args: []string{
+			strconv.Itoa(volume),
+		},
+	}
+}
+
+func CommandVolumeIncrease(amount int) Command {
+	return Command{
+		Command: "Volume Increase",
+		Args: []string{
+			strconv.Itoa(amount),
+		},
+	}
+}
+
+func CommandVolumeDecrease(amount int) Command {
+	return Command{
+		Command: "Volume Decrease",
+		Args: []string{
+			strconv.Itoa(amount),
+		},
+	}
+}
+
+// CommandOverlayModelEffect starts the named overlay effect on the given
+// models, args are passed verbatim and depend on the effect. No colour
+// correction is applied to colours in args, see Client.ColorCorrectionFor.
+func CommandOverlayModelEffect(models, autoEnable, effect string, args ...string) Command {
+	return Command{
+		Command: "Overlay Model Effect",
+		Args:    append([]string{models, autoEnable, effect}, args...),
+	}
+}
+
+// CommandOverlayModelFill fills the model with a colour, which is sent as is
+// without the colour correction Client.FillOverlaysModel applies.
+func CommandOverlayModelFill(model, state string, r, g, b int) Command {
+	return Command{
+		Command: "Overlay Model Fill",
+		Args: []string{
+			model,
+			state,
+			formatColor(r, g, b),
+		},
+	}
+}
+
+func CommandOverlayModelState(model, state string) Command {
+	return Command{
+		Command: "Overlay Model State",
+		Args: []string{
+			model,
+			state,
+		},
+	}
+}
+
+func CommandOverlayModelClear(model string) Command {
+	return Command{
+		Command: "Overlay Model Clear",
+		Args: []string{
+			model,
+		},
+	}
+}
+
+func CommandRunScript(script, args, environment string) Command {
+	return Command{
+		Command: "Run Script",
+		Args: []string{
+			script,
+			args,
+			environment,
+		},
+	}
+}
+
+func CommandURL(url, method, postData string) Command {
+	return Command{
+		Command: "URL",
+		Args: []string{
+			url,
+			method,
+			postData,
+		},
+	}
+}
+
+func CommandGPIO(pin string, on bool) Command {
+	return Command{
+		Command: "GPIO",
+		Args: []string{
+			pin,
+			strconv.FormatBool(on),
+		},
+	}
+}
+
+func CommandEffectStart(effect string, startChannel int, loop, background, ifNotRunning bool) Command {
+	return Command{
+		Command: "Effect Start",
+		Args: []string{
+			effect,
+			strconv.Itoa(startChannel),
+			strconv.FormatBool(loop),
+			strconv.FormatBool(background),
+			strconv.FormatBool(ifNotRunning),
+		},
+	}
+}
+
+func CommandEffectStop(effect string) Command {
+	return Command{
+		Command: "Effect Stop",
+		Args: []string{
+			effect,
+		},
+	}
+}
+
+func CommandEffectsStop() Command {
+	return Command{Command: "Effects Stop", Args: []string{}}
+}
+
+func CommandAllLightsOff() Command {
+	return Command{Command: "All Lights Off", Args: []string{}}
+}
+
+func CommandPlayMedia(media string, loopCount, volume int) Command {
+	return Command{
+		Command: "Play Media",
+		Args: []string{
+			media,
+			strconv.Itoa(loopCount),
+			strconv.Itoa(volume),
+		},
+	}
+}
+
+func CommandStopMedia() Command {
+	return Command{Command: "Stop Media", Args: []string{}}
+}
+
+func CommandTestStop() Command {
+	return Command{Command: "Test Stop", Args: []string{}}
+}
+
+func formatColor(r, g, b int) string {
+	return fmt.Sprintf("#%02x%02x%02x", constrainToByte(r), constrainToByte(g), constrainToByte(b))
+}
@@ -0,0 +1,162 @@
+package fppclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestPostCommand(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddPlaylist(fppclient.Playlist{Name: "Show"})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	cmd := fppclient.CommandStartPlaylist("Show", false, false)
+	res, err := c.PostCommand(context.TODO(), cmd)
+	require.NoError(t, err)
+	require.Equal(t, "Playlist Starting", res.String())
+
+	res, err = c.PostCommand(context.TODO(), fppclient.CommandStopNow())
+	require.NoError(t, err)
+	require.Equal(t, "OK", res.Status)
+
+	loud := fppclient.Command{Command: "Volume Set", Args: []string{"loud"}}
+	res, err = c.PostCommand(context.TODO(), loud)
+	require.ErrorIs(t, err, fppclient.ErrFailed)
+	require.Equal(t, `invalid volume "loud"`, res.Message)
+
+	_, err = c.PostCommand(context.TODO(), fppclient.CommandStartPlaylist("Missing", false, false))
+	require.ErrorIs(t, err, fppclient.ErrNotFound)
+
+	require.Equal(t, []fppclient.Command{
+		cmd,
+		fppclient.CommandStopNow(),
+		loud,
+		fppclient.CommandStartPlaylist("Missing", false, false),
+	}, srv.Commands())
+}
+
+func TestCommandDefinitionsDecode(t *testing.T) {
+	var defs fppclient.CommandDefinitions
+	require.NoError(t, json.Unmarshal([]byte(`[{
+		"name": "Volume Set",
+		"args": [{"name": "volume", "description": "Volume", "type": "int", "min": 0, "max": 100, "default": 70}]
+	}, {
+		"name": "Start Playlist",
+		"args": [
+			{"name": "name", "type": "string", "contentListUrl": "api/playlists/playable"},
+			{"name": "repeat", "type": "bool", "default": "false"},
+			{"name": "ifNotRunning", "type": "bool", "default": false, "optional": true}
+		]
+	}]`), &defs))
+	require.Len(t, defs, 2)
+
+	volume, ok := defs.Get("Volume Set")
+	require.True(t, ok)
+	require.Equal(t, fppclient.Stringish("70"), volume.Args[0].Default)
+	require.Equal(t, 100, *volume.Args[0].Max)
+
+	start, ok := defs.Get("Start Playlist")
+	require.True(t, ok)
+	arg, ok := start.Arg("ifNotRunning")
+	require.True(t, ok)
+	require.True(t, arg.Optional)
+	require.Equal(t, fppclient.Stringish("false"), arg.Default)
+}
+
+func TestGetCommands(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.SetCommands(loadCommandDefinitions(t))
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	defs, err := c.GetCommands(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, loadCommandDefinitions(t), defs)
+
+	_, ok := defs.Get("Volume Set")
+	require.True(t, ok)
+}
+
+// loadCommandDefinitions loads the definitions in the fixtures.
+func loadCommandDefinitions(t *testing.T) fppclient.CommandDefinitions {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", "fixtures", "CommandDefinitions", "commands.json"))
+	require.NoError(t, err)
+
+	var defs fppclient.CommandDefinitions
+	require.NoError(t, json.Unmarshal(b, &defs))
+
+	return defs
+}
+
+func TestCommandConstructors(t *testing.T) {
+	defs := loadCommandDefinitions(t)
+
+	// Args maps the argument names in the definition to the expected value.
+	checks := []struct {
+		Cmd  fppclient.Command
+		Name string
+		Args map[string]string
+	}{
+		{fppclient.CommandStartPlaylist("Show", true, false), "Start Playlist", map[string]string{"name": "Show", "repeat": "true", "ifNotRunning": "false"}},
+		{fppclient.CommandStartPlaylistAtItem("Show", 3, false, true), "Start Playlist At Item", map[string]string{"name": "Show", "item": "3", "repeat": "false", "ifNotRunning": "true"}},
+		{fppclient.CommandStartPlaylistAtRandomItem("Show", true, true), "Start Playlist At Random Item", map[string]string{"name": "Show", "repeat": "true", "ifNotRunning": "true"}},
+		{fppclient.CommandStopNow(), "Stop Now", nil},
+		{fppclient.CommandStopGracefully(true), "Stop Gracefully", map[string]string{"afterCurrentLoop": "true"}},
+		{fppclient.CommandNextPlaylistItem(), "Next Playlist Item", nil},
+		{fppclient.CommandPrevPlaylistItem(), "Prev Playlist Item", nil},
+		{fppclient.CommandPausePlaylist(), "Pause Playlist", nil},
+		{fppclient.CommandResumePlaylist(), "Resume Playlist", nil},
+		{fppclient.CommandRestartPlaylistItem(), "Restart Playlist Item", nil},
+		{fppclient.CommandInsertPlaylistAfterCurrent("Show", 1, 4, true), "Insert Playlist After Current", map[string]string{"name": "Show", "startItem": "1", "endItem": "4", "ifNotRunning": "true"}},
+		{fppclient.CommandInsertPlaylistImmediate("Show", 2, 5, false), "Insert Playlist Immediate", map[string]string{"name": "Show", "startItem": "2", "endItem": "5", "ifNotRunning": "false"}},
+		{fppclient.CommandVolumeSet(40), "Volume Set", map[string]string{"volume": "40"}},
+		{fppclient.CommandVolumeIncrease(5), "Volume Increase", map[string]string{"volume": "5"}},
+		{fppclient.CommandVolumeDecrease(6), "Volume Decrease", map[string]string{"volume": "6"}},
+		{fppclient.CommandOverlayModelEffect("Matrix", "Enabled", "Bars"), "Overlay Model Effect", map[string]string{"Models": "Matrix", "AutoEnable": "Enabled", "Effect": "Bars"}},
+		{fppclient.CommandOverlayModelFill("Matrix", "Transparent", 255, 16, 1), "Overlay Model Fill", map[string]string{"Model": "Matrix", "State": "Transparent", "Color": "#ff1001"}},
+		{fppclient.CommandOverlayModelState("Matrix", "TransparentRGB"), "Overlay Model State", map[string]string{"Model": "Matrix", "State": "TransparentRGB"}},
+		{fppclient.CommandOverlayModelClear("Matrix"), "Overlay Model Clear", map[string]string{"Model": "Matrix"}},
+		{fppclient.CommandRunScript("lights.sh", "--on", "A=1"), "Run Script", map[string]string{"Script": "lights.sh", "Script Arguments": "--on", "Environment Variables": "A=1"}},
+		{fppclient.CommandURL("http://example.com/", "POST", "x=1"), "URL", map[string]string{"URL": "http://example.com/", "Method": "POST", "Post Data": "x=1"}},
+		{fppclient.CommandGPIO("P9-12", false), "GPIO", map[string]string{"pin": "P9-12", "on": "false"}},
+		{fppclient.CommandEffectStart("snow", 100, true, false, true), "Effect Start", map[string]string{"effect": "snow", "startChannel": "100", "loop": "true", "bg": "false", "ifNotRunning": "true"}},
+		{fppclient.CommandEffectStop("snow"), "Effect Stop", map[string]string{"effect": "snow"}},
+		{fppclient.CommandEffectsStop(), "Effects Stop", nil},
+		{fppclient.CommandAllLightsOff(), "All Lights Off", nil},
+		{fppclient.CommandPlayMedia("song.mp3", 2, -10), "Play Media", map[string]string{"media": "song.mp3", "loopCount": "2", "volume": "-10"}},
+		{fppclient.CommandStopMedia(), "Stop Media", nil},
+		{fppclient.CommandTestStop(), "Test Stop", nil},
+	}
+
+	for _, check := range checks {
+		t.Run(check.Name, func(t *testing.T) {
+			require.Equal(t, check.Name, check.Cmd.Command)
+			require.NoError(t, check.Cmd.Validate(defs))
+
+			def, ok := defs.Get(check.Name)
+			require.True(t, ok)
+			require.Len(t, check.Cmd.Args, len(check.Args))
+
+			for i, v := range check.Cmd.Args {
+				require.Equal(t, check.Args[def.Args[i].Name], v, "argument %d (%s)", i, def.Args[i].Name)
+			}
+		})
+	}
+}
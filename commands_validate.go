@@ -0,0 +1,154 @@
+package fppclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// ErrInvalidCommand is wrapped by every CommandValidationError.
+var ErrInvalidCommand = errors.New("invalid command")
+
+// variadicCommands take additional arguments beyond those in their
+// definition, typically the arguments of whatever they invoke.
+var variadicCommands = map[string]bool{
+	"Overlay Model Effect": true,
+}
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// A CommandValidationError describes why a Command doesn't match its
+// definition, Index is -1 when the problem isn't with a specific argument.
+type CommandValidationError struct {
+	Command string
+	Arg     string
+	Index   int
+	Reason  string
+}
+
+func (e *CommandValidationError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("command %q: %s", e.Command, e.Reason)
+	}
+
+	return fmt.Sprintf("command %q argument %d (%s): %s", e.Command, e.Index, e.Arg, e.Reason)
+}
+
+func (e *CommandValidationError) Unwrap() error {
+	return ErrInvalidCommand
+}
+
+// Validate checks the command against the definitions returned by
+// GetCommands.
+func (cmd Command) Validate(defs CommandDefinitions) error {
+	def, ok := defs.Get(cmd.Command)
+	if !ok {
+		return &CommandValidationError{Command: cmd.Command, Index: -1, Reason: "unknown command"}
+	}
+
+	if len(cmd.Args) > len(def.Args) && !variadicCommands[cmd.Command] {
+		return &CommandValidationError{
+			Command: cmd.Command,
+			Index:   -1,
+			Reason:  fmt.Sprintf("too many arguments, expected at most %d got %d", len(def.Args), len(cmd.Args)),
+		}
+	}
+
+	for i, arg := range def.Args {
+		if i >= len(cmd.Args) {
+			if !arg.Optional {
+				return &CommandValidationError{Command: cmd.Command, Arg: arg.Name, Index: i, Reason: "missing required argument"}
+			}
+
+			continue
+		}
+
+		if reason := arg.validate(cmd.Args[i]); reason != "" {
+			return &CommandValidationError{Command: cmd.Command, Arg: arg.Name, Index: i, Reason: reason}
+		}
+	}
+
+	return nil
+}
+
+func (a CommandArg) validate(v string) string {
+	if v == "" {
+		if a.Optional || a.AllowBlanks {
+			return ""
+		}
+
+		return "value required"
+	}
+
+	switch a.Type {
+	case "int", "range":
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Sprintf("%q is not an integer", v)
+		}
+
+		if a.Min != nil && i < *a.Min {
+			return fmt.Sprintf("%d is below the minimum of %d", i, *a.Min)
+		}
+
+		if a.Max != nil && i > *a.Max {
+			return fmt.Sprintf("%d is above the maximum of %d", i, *a.Max)
+		}
+	case "bool":
+		if v != "true" && v != "false" {
+			return fmt.Sprintf("%q is not true or false", v)
+		}
+	case "color":
+		if !colorPattern.MatchString(v) {
+			return fmt.Sprintf("%q is not a #rrggbb color", v)
+		}
+	}
+
+	if len(a.Contents) > 0 {
+		for _, c := range a.Contents {
+			if c == v {
+				return ""
+			}
+		}
+
+		return fmt.Sprintf("%q is not one of %q", v, a.Contents)
+	}
+
+	return ""
+}
+
+// commandCache holds the command definitions for automatic validation, it's
+// shared by pointer as the Client is passed around by value.
+type commandCache struct {
+	mu   sync.Mutex
+	defs CommandDefinitions
+}
+
+func (cc *commandCache) get(ctx context.Context, c Client) (CommandDefinitions, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.defs != nil {
+		return cc.defs, nil
+	}
+
+	defs, err := c.GetCommands(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.defs = defs
+
+	return defs, nil
+}
+
+// WithCommandValidation validates commands before PostCommand sends them,
+// if defs is nil they are fetched from FPP on first use.
+func WithCommandValidation(defs CommandDefinitions) newArg {
+	return func(c *Client) {
+		c.commandCache = &commandCache{defs: defs}
+	}
+}
@@ -0,0 +1,76 @@
+package fppclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+var testCommandDefinitions = fppclient.CommandDefinitions{{
+	Name: "Volume Set",
+	Args: []fppclient.CommandArg{{Name: "volume", Type: "int", Min: intPtr(0), Max: intPtr(100)}},
+}, {
+	Name: "Start Playlist",
+	Args: []fppclient.CommandArg{
+		{Name: "name", Type: "string"},
+		{Name: "repeat", Type: "bool"},
+		{Name: "ifNotRunning", Type: "bool", Optional: true},
+	},
+}, {
+	Name: "Overlay Model State",
+	Args: []fppclient.CommandArg{
+		{Name: "Model", Type: "string"},
+		{Name: "State", Type: "string", Contents: []string{"Disabled", "Enabled", "Transparent", "TransparentRGB"}},
+	},
+}}
+
+func TestCommandValidate(t *testing.T) {
+	checks := []struct {
+		Cmd   fppclient.Command
+		Arg   string
+		Valid bool
+	}{
+		{fppclient.CommandVolumeSet(50), "", true},
+		{fppclient.CommandVolumeSet(150), "volume", false},
+		{fppclient.Command{Command: "Volume Set", Args: []string{"loud"}}, "volume", false},
+		{fppclient.Command{Command: "Volume Set", Args: []string{"1", "2"}}, "", false},
+		{fppclient.CommandStartPlaylist("Show", true, false), "", true},
+		{fppclient.Command{Command: "Start Playlist", Args: []string{"Show", "true"}}, "", true},
+		{fppclient.Command{Command: "Start Playlist", Args: []string{"Show"}}, "repeat", false},
+		{fppclient.Command{Command: "Start Playlist", Args: []string{"", "true"}}, "name", false},
+		{fppclient.Command{Command: "Start Playlist", Args: []string{"Show", "yes"}}, "repeat", false},
+		{fppclient.CommandOverlayModelState("Matrix", "Enabled"), "", true},
+		{fppclient.CommandOverlayModelState("Matrix", "On"), "State", false},
+		{fppclient.CommandStopNow(), "", false},
+	}
+
+	for _, check := range checks {
+		err := check.Cmd.Validate(testCommandDefinitions)
+		if check.Valid {
+			require.NoError(t, err, check.Cmd)
+			continue
+		}
+
+		require.ErrorIs(t, err, fppclient.ErrInvalidCommand, check.Cmd)
+
+		var verr *fppclient.CommandValidationError
+		require.True(t, errors.As(err, &verr))
+		require.Equal(t, check.Arg, verr.Arg, check.Cmd)
+	}
+}
+
+func TestPostCommandValidation(t *testing.T) {
+	c, err := fppclient.New("http://127.0.0.1:0", fppclient.WithCommandValidation(testCommandDefinitions))
+	require.NoError(t, err)
+
+	_, err = c.PostCommand(context.TODO(), fppclient.CommandVolumeSet(101))
+	require.ErrorIs(t, err, fppclient.ErrInvalidCommand)
+}
@@ -0,0 +1,107 @@
+package dumptransport
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"unicode/utf8"
+)
+
+// A Cassette is a recording of HTTP interactions that can be saved to disk
+// and served back by a ReplayTransport.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// An Interaction is a request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as it was sent. URL holds only the path and
+// query so recordings don't depend on, or leak, the player's address.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is saved as text when it's valid UTF-8, and base64 otherwise so
+// binary downloads survive the round trip.
+type Body []byte
+
+type encodedBody struct {
+	Base64 string `json:"base64"`
+}
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+
+	return json.Marshal(encodedBody{Base64: base64.StdEncoding.EncodeToString(b)})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var enc encodedBody
+		if err := json.Unmarshal(data, &enc); err != nil {
+			return err
+		}
+
+		raw, err := base64.StdEncoding.DecodeString(enc.Base64)
+		if err != nil {
+			return fmt.Errorf("unable to decode body: %w", err)
+		}
+
+		*b = raw
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*b = Body(s)
+	return nil
+}
+
+// LoadCassette reads a cassette saved with Cassette.Save.
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette: %w", err)
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("unable to parse cassette %q: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// Save writes the cassette to path as indented JSON.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal cassette: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("unable to write cassette: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package dumptransport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/debug/dumptransport"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 2, Height: 1})
+	srv.AddFile("sequences", "show.fseq", []byte{'P', 'S', 'E', 'Q', 0xff, 0x00}, time.Now())
+
+	rec := &dumptransport.RecordingTransport{
+		Transport: http.DefaultTransport,
+		Redactors: []dumptransport.Redactor{
+			dumptransport.RedactHeaders("Date"),
+			dumptransport.RedactJSONFields("uuid"),
+			dumptransport.RedactBody(regexp.MustCompile(`Playlist not found`), "Playlist is missing"),
+		},
+	}
+
+	c, err := fppclient.New(srv.URL, fppclient.WithHTTPClient(&http.Client{Transport: rec}))
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+
+	status, err := c.GetFPPDStatus(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, dumptransport.Redacted, status.UUID)
+
+	require.NoError(t, c.FillOverlaysModel(ctx, "Matrix", 1, 2, 3))
+
+	_, err = c.GetPlaylist(ctx, "secret")
+	require.ErrorIs(t, err, fppclient.ErrNotFound)
+
+	rc, err := c.DownloadFile(ctx, "sequences", "show.fseq")
+	require.NoError(t, err)
+	rc.Close()
+
+	file := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, rec.Save(file))
+
+	cassette, err := dumptransport.LoadCassette(file)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 4)
+	require.Equal(t, "/api/fppd/status", cassette.Interactions[0].Request.URL)
+	require.Equal(t, dumptransport.Redacted, cassette.Interactions[0].Response.Header.Get("Date"))
+	require.Contains(t, string(cassette.Interactions[0].Response.Body), `"uuid":"REDACTED"`)
+	require.Contains(t, string(cassette.Interactions[2].Response.Body), "Playlist is missing")
+	require.Equal(t, []byte{'P', 'S', 'E', 'Q', 0xff, 0x00}, []byte(cassette.Interactions[3].Response.Body))
+
+	// Replay without the server.
+	srv.Close()
+
+	replay := &dumptransport.ReplayTransport{
+		Cassette: cassette,
+		Matchers: []dumptransport.Matcher{dumptransport.MatchMethod, dumptransport.MatchURL, dumptransport.MatchJSONBody},
+	}
+
+	c, err = fppclient.New(srv.URL, fppclient.WithHTTPClient(&http.Client{Transport: replay}))
+	require.NoError(t, err)
+
+	replayed, err := c.GetFPPDStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, dumptransport.Redacted, replayed.UUID)
+	require.Equal(t, status.StatusName, replayed.StatusName)
+
+	require.Len(t, replay.Remaining(), 3)
+
+	require.NoError(t, c.FillOverlaysModel(ctx, "Matrix", 1, 2, 3))
+
+	err = c.FillOverlaysModel(ctx, "Matrix", 3, 2, 1)
+	require.True(t, errors.Is(err, dumptransport.ErrNoInteraction), err)
+
+	_, err = c.GetPlaylist(ctx, "secret")
+	require.ErrorIs(t, err, fppclient.ErrNotFound)
+	require.ErrorContains(t, err, "Playlist is missing")
+
+	// Served again once used up.
+	_, err = c.GetFPPDStatus(ctx)
+	require.NoError(t, err)
+}
+
+func TestDumpTransportError(t *testing.T) {
+	replay := &dumptransport.ReplayTransport{Cassette: &dumptransport.Cassette{}}
+
+	c, err := fppclient.New("http://fpp.invalid", fppclient.WithHTTPClient(&http.Client{
+		Transport: &dumptransport.DumpTransport{Transport: replay},
+	}))
+	require.NoError(t, err)
+
+	_, err = c.GetFPPDStatus(context.TODO())
+	require.ErrorIs(t, err, dumptransport.ErrNoInteraction)
+}
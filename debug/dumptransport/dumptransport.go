@@ -18,11 +18,25 @@ func (d *DumpTransport) RoundTrip(h *http.Request) (*http.Response, error) {
 	color.Red(string(dump))
 	fmt.Println("")
 
-	resp, err := d.Transport.RoundTrip(h)
-	dump, _ = httputil.DumpResponse(resp, true)
-	color.HiGreen("**** RESPONSE ****")
-	color.Green(string(dump))
+	resp, err := transport(d.Transport).RoundTrip(h)
+	if err != nil {
+		color.HiYellow("**** ERROR ****")
+		color.Yellow(err.Error())
+	} else {
+		dump, _ = httputil.DumpResponse(resp, true)
+		color.HiGreen("**** RESPONSE ****")
+		color.Green(string(dump))
+	}
+
 	color.HiBlue("\n********************")
 	fmt.Println("")
 	return resp, err
 }
+
+func transport(t http.RoundTripper) http.RoundTripper {
+	if t == nil {
+		return http.DefaultTransport
+	}
+
+	return t
+}
@@ -0,0 +1,128 @@
+package dumptransport
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+)
+
+// Redacted replaces anything removed by a Redactor.
+const Redacted = "REDACTED"
+
+// A Redactor scrubs sensitive details from an interaction before it's
+// recorded.
+type Redactor func(i *Interaction)
+
+// RedactHeaders replaces the values of the named request and response
+// headers.
+func RedactHeaders(names ...string) Redactor {
+	return func(i *Interaction) {
+		for _, name := range names {
+			for _, h := range []http.Header{i.Request.Header, i.Response.Header} {
+				if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+					h.Set(name, Redacted)
+				}
+			}
+		}
+	}
+}
+
+// RedactBody replaces everything matching re in request and response bodies
+// with repl, which may refer to submatches as in regexp.Regexp.ReplaceAll.
+func RedactBody(re *regexp.Regexp, repl string) Redactor {
+	return func(i *Interaction) {
+		i.Request.Body = re.ReplaceAll(i.Request.Body, []byte(repl))
+		i.Response.Body = re.ReplaceAll(i.Response.Body, []byte(repl))
+	}
+}
+
+// RedactJSONFields replaces the string values of the named fields wherever
+// they appear in JSON bodies, e.g. RedactJSONFields("uuid").
+func RedactJSONFields(fields ...string) Redactor {
+	redactors := make([]Redactor, len(fields))
+	for n, field := range fields {
+		re := regexp.MustCompile(`("` + regexp.QuoteMeta(field) + `"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+		redactors[n] = RedactBody(re, `${1}"`+Redacted+`"`)
+	}
+
+	return func(i *Interaction) {
+		for _, r := range redactors {
+			r(i)
+		}
+	}
+}
+
+// RecordingTransport passes requests on to Transport and records every
+// interaction, call Save once done to write them out as a cassette.
+type RecordingTransport struct {
+	Transport http.RoundTripper
+	Redactors []Redactor
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+func (r *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := transport(r.Transport).RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	i := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: req.Header.Clone(),
+			Body:   reqBody,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       append([]byte(nil), respBody...),
+		},
+	}
+
+	for _, redact := range r.Redactors {
+		redact(&i)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Cassette returns a copy of what has been recorded so far.
+func (r *RecordingTransport) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes what has been recorded so far to path.
+func (r *RecordingTransport) Save(path string) error {
+	return r.Cassette().Save(path)
+}
@@ -0,0 +1,164 @@
+package dumptransport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// ErrNoInteraction is returned by ReplayTransport when nothing on the
+// cassette matches a request.
+var ErrNoInteraction = errors.New("no matching interaction")
+
+// A Matcher reports whether a recorded request matches req, body is the
+// body of req which has already been read.
+type Matcher func(req *http.Request, body []byte, rec RecordedRequest) bool
+
+// MatchMethod matches on the HTTP method.
+func MatchMethod(req *http.Request, _ []byte, rec RecordedRequest) bool {
+	return req.Method == rec.Method
+}
+
+// MatchURL matches on the path and query.
+func MatchURL(req *http.Request, _ []byte, rec RecordedRequest) bool {
+	return req.URL.RequestURI() == rec.URL
+}
+
+// MatchBody matches bodies byte for byte.
+func MatchBody(_ *http.Request, body []byte, rec RecordedRequest) bool {
+	return bytes.Equal(body, rec.Body)
+}
+
+// MatchJSONBody matches bodies that decode to the same JSON, ignoring
+// formatting and key order. Bodies that aren't JSON are compared exactly.
+func MatchJSONBody(_ *http.Request, body []byte, rec RecordedRequest) bool {
+	var a, b interface{}
+	if json.Unmarshal(body, &a) != nil || json.Unmarshal(rec.Body, &b) != nil {
+		return bytes.Equal(body, rec.Body)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// MatchHeaders matches on the values of the named headers.
+func MatchHeaders(names ...string) Matcher {
+	return func(req *http.Request, _ []byte, rec RecordedRequest) bool {
+		for _, name := range names {
+			if !reflect.DeepEqual(req.Header.Values(name), rec.Header.Values(name)) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// DefaultMatchers are used by a ReplayTransport without any Matchers.
+var DefaultMatchers = []Matcher{MatchMethod, MatchURL}
+
+// ReplayTransport serves responses from a cassette instead of the network.
+//
+// Matching interactions are served in the order they were recorded, each
+// once, after which the last of them is repeated. This lets a recording of a
+// status being polled play out as it happened.
+type ReplayTransport struct {
+	Cassette *Cassette
+	Matchers []Matcher
+
+	mu   sync.Mutex
+	used map[int]bool
+}
+
+func (r *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+
+		req.Body.Close()
+	}
+
+	i, ok := r.match(req, body)
+	if !ok {
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.RequestURI(), ErrNoInteraction)
+	}
+
+	header := i.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(i.Response.StatusCode) + " " + http.StatusText(i.Response.StatusCode),
+		StatusCode:    i.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(i.Response.Body)),
+		ContentLength: int64(len(i.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *ReplayTransport) match(req *http.Request, body []byte) (Interaction, bool) {
+	matchers := r.Matchers
+	if len(matchers) == 0 {
+		matchers = DefaultMatchers
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.used == nil {
+		r.used = map[int]bool{}
+	}
+
+	last := -1
+
+interactions:
+	for n, i := range r.Cassette.Interactions {
+		for _, m := range matchers {
+			if !m(req, body, i.Request) {
+				continue interactions
+			}
+		}
+
+		if !r.used[n] {
+			r.used[n] = true
+			return i, true
+		}
+
+		last = n
+	}
+
+	if last < 0 {
+		return Interaction{}, false
+	}
+
+	return r.Cassette.Interactions[last], true
+}
+
+// Remaining returns the interactions that haven't been served yet, handy for
+// checking a test made every request that was recorded.
+func (r *ReplayTransport) Remaining() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var remaining []Interaction
+	for n, i := range r.Cassette.Interactions {
+		if !r.used[n] {
+			remaining = append(remaining, i)
+		}
+	}
+
+	return remaining
+}
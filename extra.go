@@ -0,0 +1,176 @@
+package fppclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Extra holds the JSON fields a structure doesn't know about so that they
+// survive being sent back to FPP.
+type Extra map[string]json.RawMessage
+
+// unmarshalWithExtra decodes data into v, which must be a pointer to a struct
+// without custom unmarshalling, and returns any fields v doesn't define.
+func unmarshalWithExtra(data []byte, v interface{}) (Extra, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+
+	var extra Extra
+	for k, raw := range all {
+		if isKnownField(known, k) {
+			continue
+		}
+
+		if extra == nil {
+			extra = Extra{}
+		}
+
+		extra[k] = raw
+	}
+
+	return extra, nil
+}
+
+// marshalWithExtra encodes v and merges in any extra fields it doesn't
+// already have.
+func marshalWithExtra(v interface{}, extra Extra) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+
+	for k, raw := range extra {
+		if _, exists := all[k]; !exists {
+			all[k] = raw
+		}
+	}
+
+	return json.Marshal(all)
+}
+
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+
+		if tag == "-" || !f.IsExported() {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			names = append(names, jsonFieldNames(f.Type)...)
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// isKnownField matches the way encoding/json matches keys to fields.
+func isKnownField(known []string, key string) bool {
+	for _, k := range known {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rawObject is a JSON object that remembers the order of its keys.
+type rawObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+func parseRawObject(data []byte) (rawObject, error) {
+	var obj rawObject
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return obj, err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return obj, err
+		}
+
+		key, ok := tok.(string)
+		if !ok {
+			return obj, fmt.Errorf("unexpected %v in object", tok)
+		}
+
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return obj, err
+		}
+
+		obj.set(key, v)
+	}
+
+	return obj, nil
+}
+
+func (o *rawObject) set(key string, v json.RawMessage) {
+	if o.values == nil {
+		o.values = map[string]json.RawMessage{}
+	}
+
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = v
+}
+
+func (o rawObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(o.values[k])
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
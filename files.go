@@ -3,6 +3,10 @@ package fppclient
 import (
 	"context"
 	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
 )
 
 func (c Client) GetFiles(ctx context.Context, dir string) (files []File, err error) {
@@ -15,6 +19,137 @@ func (c Client) GetFiles(ctx context.Context, dir string) (files []File, err err
 	return f.Files, err
 }
 
+type uploadConfig struct {
+	progress func(written int64)
+}
+
+type UploadOption func(u *uploadConfig)
+
+// WithUploadProgress is called with the running total of bytes sent.
+func WithUploadProgress(fn func(written int64)) UploadOption {
+	return func(u *uploadConfig) {
+		u.progress = fn
+	}
+}
+
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	fn      func(int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	p.fn(p.written)
+
+	return n, err
+}
+
+// UploadFile streams r to FPP as dir/name. The body is sent as it's read so
+// a http client with a generous timeout is needed for large files.
+func (c Client) UploadFile(ctx context.Context, dir, name string, r io.Reader, opts ...UploadOption) error {
+	var cfg uploadConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	path := fmt.Sprintf("/api/file/%s", dir)
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		part, err := mw.CreateFormFile("file", name)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		var w io.Writer = part
+		if cfg.progress != nil {
+			w = &progressWriter{w: part, fn: cfg.progress}
+		}
+
+		if _, err := io.Copy(w, r); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		pw.CloseWithError(mw.Close())
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.formatURL(path), pr)
+	if err != nil {
+		pr.Close()
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	body, _, err := c.httpDoRaw(req)
+	pr.Close()
+
+	if err != nil {
+		return fmt.Errorf("unable to upload %s/%s: %w", dir, name, err)
+	}
+
+	if status, ok := parseStatus(body); ok {
+		if err := status.check(http.MethodPost, path); err != nil {
+			return fmt.Errorf("unable to upload %s/%s: %w", dir, name, err)
+		}
+	}
+
+	return nil
+}
+
+// DownloadFile returns the contents of dir/name, the caller must close it.
+func (c Client) DownloadFile(ctx context.Context, dir, name string) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/api/file/%s/%s", dir, name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.formatURL(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+
+	resp, err := c.httpDoResponse(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download %s/%s: %w", dir, name, err)
+	}
+
+	return resp.Body, nil
+}
+
+func (c Client) DeleteFile(ctx context.Context, dir, name string) error {
+	path := fmt.Sprintf("/api/file/%s/%s", dir, name)
+
+	if err := c.httpDoStatus(ctx, http.MethodDelete, path, nil); err != nil {
+		return fmt.Errorf("unable to delete %s/%s: %w", dir, name, err)
+	}
+
+	return nil
+}
+
+func (c Client) RenameFile(ctx context.Context, dir, oldName, newName string) error {
+	path := fmt.Sprintf("/api/file/%s/rename/%s/%s", dir, oldName, newName)
+
+	if err := c.httpDoStatus(ctx, http.MethodPost, path, nil); err != nil {
+		return fmt.Errorf("unable to rename %s/%s: %w", dir, oldName, err)
+	}
+
+	return nil
+}
+
+func (c Client) CopyFile(ctx context.Context, dir, srcName, dstName string) error {
+	path := fmt.Sprintf("/api/file/%s/copy/%s/%s", dir, srcName, dstName)
+
+	if err := c.httpDoStatus(ctx, http.MethodPost, path, nil); err != nil {
+		return fmt.Errorf("unable to copy %s/%s: %w", dir, srcName, err)
+	}
+
+	return nil
+}
+
 type Files struct {
 	Status string `json:"status"`
 	Files  []File `json:"files"`
@@ -25,3 +160,22 @@ type File struct {
 	SizeBytes int    `json:"sizeBytes"`
 	SizeHuman string `json:"sizeHuman"`
 }
+
+// fileMtimeLayouts are the formats FPP has used for File.Mtime.
+var fileMtimeLayouts = []string{
+	"01/02/06  03:04 PM",
+	"01/02/06 03:04 PM",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
+// ModTime parses Mtime, which FPP reports in its local time.
+func (f File) ModTime(loc *time.Location) (time.Time, error) {
+	for _, layout := range fileMtimeLayouts {
+		if t, err := time.ParseInLocation(layout, f.Mtime, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised mtime %q", f.Mtime)
+}
@@ -0,0 +1,58 @@
+package fppclient_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestFileTransfer(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+	content := strings.Repeat("fseq", 10000)
+
+	var progress int64
+	require.NoError(t, c.UploadFile(ctx, "sequences", "Test.fseq", strings.NewReader(content), fppclient.WithUploadProgress(func(n int64) {
+		progress = n
+	})))
+	stored, ok := srv.File("sequences", "Test.fseq")
+	require.True(t, ok)
+	require.Equal(t, content, string(stored))
+	require.Equal(t, int64(len(content)), progress)
+
+	rc, err := c.DownloadFile(ctx, "sequences", "Test.fseq")
+	require.NoError(t, err)
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, content, string(b))
+
+	require.NoError(t, c.RenameFile(ctx, "sequences", "Test.fseq", "Renamed.fseq"))
+	require.NoError(t, c.CopyFile(ctx, "sequences", "Renamed.fseq", "Copy.fseq"))
+	require.NoError(t, c.DeleteFile(ctx, "sequences", "Copy.fseq"))
+
+	files, err := c.GetFiles(ctx, "sequences")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "Renamed.fseq", files[0].Name)
+
+	require.Equal(t, []string{
+		"POST /api/file/sequences",
+		"GET /api/file/sequences/Test.fseq",
+		"POST /api/file/sequences/rename/Test.fseq/Renamed.fseq",
+		"POST /api/file/sequences/copy/Renamed.fseq/Copy.fseq",
+		"DELETE /api/file/sequences/Copy.fseq",
+		"GET /api/files/sequences",
+	}, srv.Requests())
+}
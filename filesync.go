@@ -0,0 +1,342 @@
+package fppclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+type SyncOptions struct {
+	// DryRun works out what would be done without doing it.
+	DryRun bool
+
+	// Delete removes remote files that don't exist locally.
+	Delete bool
+
+	// Checksum compares file contents rather than size and mtime, this
+	// downloads every remote file that has the same size as its local copy,
+	// Concurrency at a time.
+	Checksum bool
+
+	// Include and Exclude are path.Match globs tested against file names,
+	// a file must match an include (if any) and no excludes.
+	Include []string
+	Exclude []string
+
+	// Concurrency is the number of simultaneous transfers, defaults to 1.
+	Concurrency int
+
+	// Location is the time zone of the player, defaults to time.Local.
+	Location *time.Location
+}
+
+func (o SyncOptions) matches(name string) (bool, error) {
+	for _, pattern := range o.Exclude {
+		if ok, err := path.Match(pattern, name); err != nil || ok {
+			return false, err
+		}
+	}
+
+	if len(o.Include) == 0 {
+		return true, nil
+	}
+
+	for _, pattern := range o.Include {
+		if ok, err := path.Match(pattern, name); err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+type SyncActionType int
+
+const (
+	SyncUpload SyncActionType = iota
+	SyncDelete
+)
+
+func (t SyncActionType) String() string {
+	if t == SyncDelete {
+		return "delete"
+	}
+
+	return "upload"
+}
+
+type SyncAction struct {
+	Type   SyncActionType
+	Name   string
+	Reason string
+	Err    error
+}
+
+func (a SyncAction) String() string {
+	return fmt.Sprintf("%s %s (%s)", a.Type, a.Name, a.Reason)
+}
+
+type SyncResult struct {
+	Actions []SyncAction
+}
+
+// Failed returns the actions that were attempted and didn't succeed.
+func (r SyncResult) Failed() []SyncAction {
+	var failed []SyncAction
+	for _, a := range r.Actions {
+		if a.Err != nil {
+			failed = append(failed, a)
+		}
+	}
+
+	return failed
+}
+
+// SyncDir makes the files in remoteDir on FPP match the files in localPath,
+// subdirectories are ignored. Errors with individual transfers are recorded
+// against their action, the returned error is for anything that stopped the
+// sync from happening at all.
+func (c Client) SyncDir(ctx context.Context, localPath, remoteDir string, opts SyncOptions) (SyncResult, error) {
+	var result SyncResult
+
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+
+	remoteFiles, err := c.GetFiles(ctx, remoteDir)
+	if err != nil {
+		return result, fmt.Errorf("unable to sync %q: %w", remoteDir, err)
+	}
+
+	remote := make(map[string]File, len(remoteFiles))
+	for _, f := range remoteFiles {
+		remote[f.Name] = f
+	}
+
+	entries, err := os.ReadDir(localPath)
+	if err != nil {
+		return result, fmt.Errorf("unable to sync %q: %w", remoteDir, err)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	local := map[string]bool{}
+
+	// Files the same size on both sides, compared by checksum below.
+	var compare []string
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		if ok, err := opts.matches(entry.Name()); err != nil {
+			return result, fmt.Errorf("unable to sync %q: %w", remoteDir, err)
+		} else if !ok {
+			continue
+		}
+
+		local[entry.Name()] = true
+
+		info, err := entry.Info()
+		if err != nil {
+			return result, fmt.Errorf("unable to sync %q: %w", remoteDir, err)
+		}
+
+		reason := syncReason(info, remote, opts)
+		if reason == "" && opts.Checksum {
+			compare = append(compare, entry.Name())
+			continue
+		}
+
+		if reason != "" {
+			result.Actions = append(result.Actions, SyncAction{Type: SyncUpload, Name: entry.Name(), Reason: reason})
+		}
+	}
+
+	differ, err := c.contentDiffers(ctx, localPath, remoteDir, compare, concurrency)
+	if err != nil {
+		return result, fmt.Errorf("unable to sync %q: %w", remoteDir, err)
+	}
+
+	for _, name := range differ {
+		result.Actions = append(result.Actions, SyncAction{Type: SyncUpload, Name: name, Reason: "checksum differs"})
+	}
+
+	if opts.Delete {
+		for name := range remote {
+			if local[name] {
+				continue
+			}
+
+			if ok, err := opts.matches(name); err != nil {
+				return result, fmt.Errorf("unable to sync %q: %w", remoteDir, err)
+			} else if ok {
+				result.Actions = append(result.Actions, SyncAction{Type: SyncDelete, Name: name, Reason: "not present locally"})
+			}
+		}
+	}
+
+	sort.SliceStable(result.Actions, func(i, j int) bool {
+		return result.Actions[i].Name < result.Actions[j].Name
+	})
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i := range result.Actions {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(a *SyncAction) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			a.Err = c.applySyncAction(ctx, localPath, remoteDir, *a)
+		}(&result.Actions[i])
+	}
+
+	wg.Wait()
+
+	return result, ctx.Err()
+}
+
+// syncReason explains why the local file needs uploading, or returns an
+// empty string if it doesn't. In checksum mode an empty string means the
+// contents still need comparing.
+func syncReason(info os.FileInfo, remote map[string]File, opts SyncOptions) string {
+	r, exists := remote[info.Name()]
+	if !exists {
+		return "not present on player"
+	}
+
+	if int64(r.SizeBytes) != info.Size() {
+		return "size differs"
+	}
+
+	if opts.Checksum {
+		return ""
+	}
+
+	mtime, err := r.ModTime(opts.Location)
+	if err != nil {
+		return "unknown remote mtime"
+	}
+
+	// FPP only reports the mtime to the minute.
+	if info.ModTime().Truncate(time.Minute).After(mtime) {
+		return "newer locally"
+	}
+
+	return ""
+}
+
+// contentDiffers downloads the named files, concurrency at a time, and
+// returns those whose contents differ from the local copy.
+func (c Client) contentDiffers(ctx context.Context, localPath, remoteDir string, names []string, concurrency int) ([]string, error) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		differ   []string
+		firstErr error
+	)
+
+	sem := make(chan struct{}, concurrency)
+
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			same, err := c.sameContent(ctx, filepath.Join(localPath, name), remoteDir, name)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err != nil && firstErr == nil:
+				firstErr = err
+			case err == nil && !same:
+				differ = append(differ, name)
+			}
+		}(name)
+	}
+
+	wg.Wait()
+
+	return differ, firstErr
+}
+
+func (c Client) sameContent(ctx context.Context, localFile, remoteDir, name string) (bool, error) {
+	localSum, err := fileChecksum(localFile)
+	if err != nil {
+		return false, err
+	}
+
+	rc, err := c.DownloadFile(ctx, remoteDir, name)
+	if err != nil {
+		return false, err
+	}
+
+	defer rc.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return false, fmt.Errorf("unable to download %s/%s: %w", remoteDir, name, err)
+	}
+
+	return bytes.Equal(localSum, h.Sum(nil)), nil
+}
+
+func fileChecksum(name string) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+func (c Client) applySyncAction(ctx context.Context, localPath, remoteDir string, a SyncAction) error {
+	if a.Type == SyncDelete {
+		return c.DeleteFile(ctx, remoteDir, a.Name)
+	}
+
+	f, err := os.Open(filepath.Join(localPath, a.Name))
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return c.UploadFile(ctx, remoteDir, a.Name, f)
+}
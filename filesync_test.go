@@ -0,0 +1,132 @@
+package fppclient_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestSyncDir(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Date(2023, time.December, 1, 19, 0, 0, 0, time.UTC)
+
+	for name, content := range map[string]string{
+		"Same.fseq":    "same",
+		"Resized.fseq": "longer now",
+		"New.fseq":     "new",
+		"Skip.tmp":     "skip",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(filepath.Join(dir, name), old, old))
+	}
+
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddFile("sequences", "Same.fseq", []byte("same"), old)
+	srv.AddFile("sequences", "Resized.fseq", []byte("short"), old)
+	srv.AddFile("sequences", "Stale.fseq", []byte("stale"), old)
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	opts := fppclient.SyncOptions{
+		DryRun:      true,
+		Delete:      true,
+		Exclude:     []string{"*.tmp"},
+		Concurrency: 2,
+		Location:    time.UTC,
+	}
+
+	result, err := c.SyncDir(context.TODO(), dir, "sequences", opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{"GET /api/files/sequences"}, srv.Requests())
+
+	var got []string
+	for _, a := range result.Actions {
+		got = append(got, a.String())
+	}
+
+	require.Equal(t, []string{
+		"upload New.fseq (not present on player)",
+		"upload Resized.fseq (size differs)",
+		"delete Stale.fseq (not present locally)",
+	}, got)
+
+	opts.DryRun = false
+	result, err = c.SyncDir(context.TODO(), dir, "sequences", opts)
+	require.NoError(t, err)
+	require.Empty(t, result.Failed())
+	require.ElementsMatch(t, []string{
+		"GET /api/files/sequences",
+		"GET /api/files/sequences",
+		"POST /api/file/sequences",
+		"POST /api/file/sequences",
+		"DELETE /api/file/sequences/Stale.fseq",
+	}, srv.Requests())
+
+	files, err := c.GetFiles(context.TODO(), "sequences")
+	require.NoError(t, err)
+
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+
+	require.Equal(t, []string{"New.fseq", "Resized.fseq", "Same.fseq"}, names)
+}
+
+func TestSyncDirChecksum(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	dir := t.TempDir()
+	now := time.Now()
+
+	for name, content := range map[string]string{
+		"One.fseq":   "same",
+		"Two.fseq":   "same",
+		"Three.fseq": "diff",
+		"Four.fseq":  "diff",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+		srv.AddFile("sequences", name, []byte("same"), now)
+	}
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	srv.SetLatency(50 * time.Millisecond)
+
+	result, err := c.SyncDir(context.TODO(), dir, "sequences", fppclient.SyncOptions{
+		DryRun:      true,
+		Checksum:    true,
+		Concurrency: 2,
+	})
+	require.NoError(t, err)
+
+	// The four downloads are shared between two workers.
+	require.Equal(t, 2, srv.PeakInFlight())
+
+	var got []string
+	for _, a := range result.Actions {
+		got = append(got, a.String())
+	}
+
+	require.Equal(t, []string{
+		"upload Four.fseq (checksum differs)",
+		"upload Three.fseq (checksum differs)",
+	}, got)
+
+	srv.Fail(fpptest.Failure{Path: "/api/file/sequences/Two.fseq"})
+
+	_, err = c.SyncDir(context.TODO(), dir, "sequences", fppclient.SyncOptions{DryRun: true, Checksum: true, Concurrency: 4})
+	require.ErrorIs(t, err, fppclient.ErrServer)
+}
@@ -0,0 +1,116 @@
+package fpptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/freman/fppclient"
+)
+
+func (s *Server) routeCommands(mux *router) {
+	mux.HandleFunc("GET /api/commands", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.commandDefs == nil {
+			writeJSON(w, fppclient.CommandDefinitions{})
+			return
+		}
+
+		writeJSON(w, s.commandDefs)
+	})
+
+	mux.HandleFunc("POST /api/command", func(w http.ResponseWriter, r *http.Request) {
+		var cmd fppclient.Command
+		if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.commandDefs != nil {
+			if _, ok := s.commandDefs.Get(cmd.Command); !ok {
+				writeError(w, http.StatusNotFound, fmt.Sprintf("Command %q not found", cmd.Command))
+				return
+			}
+		}
+
+		s.commands = append(s.commands, cmd)
+
+		reply, err := s.runCommand(cmd)
+		if err != nil {
+			writeError(w, http.StatusOK, err.Error())
+			return
+		}
+
+		if reply != "" {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(reply)) //nolint:errcheck
+			return
+		}
+
+		writeOK(w)
+	})
+}
+
+// runCommand applies the effect of the commands that change what the fake
+// reports, everything else is only recorded. Commands FPP answers in plain
+// text return the reply. The caller must hold the lock.
+func (s *Server) runCommand(cmd fppclient.Command) (string, error) {
+	arg := func(i int) string {
+		if i < len(cmd.Args) {
+			return cmd.Args[i]
+		}
+
+		return ""
+	}
+
+	switch cmd.Command {
+	case "Start Playlist", "Start Playlist At Item":
+		name := arg(0)
+		if _, ok := s.playlists[name]; !ok {
+			return "", fmt.Errorf("playlist %q not found", name)
+		}
+
+		index := 1
+		if cmd.Command == "Start Playlist At Item" {
+			if i, err := strconv.Atoi(arg(1)); err == nil && i > 1 {
+				index = i
+			}
+		}
+
+		s.setPlaying(name, index)
+
+		return "Playlist Starting", nil
+	case "Stop Now", "Stop Gracefully":
+		s.setIdle()
+	case "Pause Playlist":
+		s.setPaused(true)
+	case "Resume Playlist":
+		s.setPaused(false)
+	case "Next Playlist Item":
+		s.advance(1)
+	case "Prev Playlist Item":
+		s.advance(-1)
+	case "Volume Set":
+		volume, err := strconv.Atoi(arg(0))
+		if err != nil {
+			return "", fmt.Errorf("invalid volume %q", arg(0))
+		}
+
+		s.status["volume"] = volume
+	case "Overlay Model Effect":
+		for _, name := range strings.Split(arg(0), ",") {
+			if m, ok := s.models[name]; ok {
+				m.EffectRunning = arg(2) != "Stop Effects"
+			}
+		}
+	}
+
+	return "", nil
+}
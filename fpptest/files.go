@@ -0,0 +1,167 @@
+package fpptest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/freman/fppclient"
+)
+
+// fileMtimeLayout is how FPP formats File.Mtime.
+const fileMtimeLayout = "01/02/06  03:04 PM"
+
+type file struct {
+	data  []byte
+	mtime time.Time
+}
+
+func (f file) info(name string) fppclient.File {
+	return fppclient.File{
+		Name:      name,
+		Mtime:     f.mtime.Format(fileMtimeLayout),
+		SizeBytes: len(f.data),
+		SizeHuman: humanSize(len(f.data)),
+	}
+}
+
+func humanSize(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := unit, 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.2f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+func (s *Server) routeFiles(mux *router) {
+	mux.HandleFunc("GET /api/files/{dir}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		dir := s.files[pathValue(r, "dir")]
+
+		names := make([]string, 0, len(dir))
+		for name := range dir {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		res := fppclient.Files{Status: "ok", Files: []fppclient.File{}}
+		for _, name := range names {
+			res.Files = append(res.Files, dir[name].info(name))
+		}
+
+		writeJSON(w, res)
+	})
+
+	mux.HandleFunc("POST /api/file/{dir}", func(w http.ResponseWriter, r *http.Request) {
+		part, header, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "No file uploaded")
+			return
+		}
+
+		defer part.Close()
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.AddFile(pathValue(r, "dir"), header.Filename, data, time.Now())
+		writeOK(w)
+	})
+
+	mux.HandleFunc("GET /api/file/{dir}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		f, ok := s.file(pathValue(r, "dir"), pathValue(r, "name"))
+		if !ok {
+			writeError(w, http.StatusNotFound, "File not found")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(f.data) //nolint:errcheck
+	})
+
+	mux.HandleFunc("DELETE /api/file/{dir}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		dir, name := pathValue(r, "dir"), pathValue(r, "name")
+		if _, ok := s.files[dir][name]; !ok {
+			writeError(w, http.StatusNotFound, "File not found")
+			return
+		}
+
+		delete(s.files[dir], name)
+		writeOK(w)
+	})
+
+	mux.HandleFunc("POST /api/file/{dir}/rename/{src}/{dst}", func(w http.ResponseWriter, r *http.Request) {
+		s.copyFile(w, r, true)
+	})
+
+	mux.HandleFunc("POST /api/file/{dir}/copy/{src}/{dst}", func(w http.ResponseWriter, r *http.Request) {
+		s.copyFile(w, r, false)
+	})
+}
+
+func (s *Server) copyFile(w http.ResponseWriter, r *http.Request, remove bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, src, dst := pathValue(r, "dir"), pathValue(r, "src"), pathValue(r, "dst")
+
+	f, ok := s.files[dir][src]
+	if !ok {
+		writeError(w, http.StatusNotFound, "File not found")
+		return
+	}
+
+	if remove {
+		delete(s.files[dir], src)
+	} else {
+		f.data = append([]byte(nil), f.data...)
+	}
+
+	s.files[dir][dst] = f
+	writeOK(w)
+}
+
+func (s *Server) file(dir, name string) (file, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[dir][name]
+	return f, ok
+}
+
+// AddFile stores a file on the fake in one of FPP's media directories, such
+// as sequences or music.
+func (s *Server) AddFile(dir, name string, data []byte, mtime time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.files[dir] == nil {
+		s.files[dir] = map[string]file{}
+	}
+
+	s.files[dir][name] = file{data: append([]byte(nil), data...), mtime: mtime}
+}
+
+// File returns the contents of dir/name.
+func (s *Server) File(dir, name string) ([]byte, bool) {
+	f, ok := s.file(dir, name)
+	return f.data, ok
+}
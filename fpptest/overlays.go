@@ -0,0 +1,337 @@
+package fpptest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/freman/fppclient"
+)
+
+type model struct {
+	fppclient.Model
+
+	data      []int
+	locked    bool
+	texts     []Text
+	lastWrite *DataWrite
+}
+
+// DataWrite is the body of a write to a model's data, as it was sent.
+type DataWrite struct {
+	RLE  bool
+	Data []int
+}
+
+// Text is what was sent to display text on a model.
+type Text struct {
+	Message         string
+	Color           string
+	Font            string
+	FontSize        int
+	AntiAlias       bool
+	Position        string
+	PixelsPerSecond int
+	AutoEnable      bool
+}
+
+func (m *model) fill(r, g, b int) {
+	for i := 0; i+2 < len(m.data); i += 3 {
+		m.data[i], m.data[i+1], m.data[i+2] = r, g, b
+	}
+}
+
+func (s *Server) routeOverlays(mux *router) {
+	mux.HandleFunc("GET /api/overlays/models", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		models := fppclient.Models{}
+		for _, name := range s.order {
+			models = append(models, s.models[name].Model)
+		}
+
+		writeJSON(w, models)
+	})
+
+	mux.HandleFunc("GET /api/overlays/fonts", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		writeJSON(w, s.fonts)
+	})
+
+	mux.HandleFunc("GET /api/overlays/effects", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		names := []string{}
+		for _, effect := range s.effects {
+			names = append(names, effect.Name)
+		}
+
+		writeJSON(w, names)
+	})
+
+	mux.HandleFunc("GET /api/overlays/effects/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		effect, ok := s.effects.Get(pathValue(r, "name"))
+		if !ok {
+			writeError(w, http.StatusNotFound, "Effect not found")
+			return
+		}
+
+		writeJSON(w, effect)
+	})
+
+	mux.HandleFunc("GET /api/overlays/model/{name}", s.withModel(func(w http.ResponseWriter, r *http.Request, m *model) {
+		writeJSON(w, m.Model)
+	}))
+
+	getData := func(rle bool) http.HandlerFunc {
+		return s.withModel(func(w http.ResponseWriter, r *http.Request, m *model) {
+			data := append([]int(nil), m.data...)
+			if rle {
+				var err error
+				if data, err = fppclient.EncodeRLE(data); err != nil {
+					writeError(w, http.StatusInternalServerError, err.Error())
+					return
+				}
+			}
+
+			writeJSON(w, fppclient.ModelData{
+				Data:          data,
+				EffectRunning: m.EffectRunning,
+				IsLocked:      m.locked,
+				RLE:           rle,
+			})
+		})
+	}
+
+	mux.HandleFunc("GET /api/overlays/model/{name}/data", getData(false))
+	mux.HandleFunc("GET /api/overlays/model/{name}/data/rle", getData(true))
+
+	mux.HandleFunc("PUT /api/overlays/model/{name}/data", s.withModel(func(w http.ResponseWriter, r *http.Request, m *model) {
+		var req struct {
+			RLE  bool  `json:"rle"`
+			Data []int `json:"data"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON")
+			return
+		}
+
+		m.lastWrite = &DataWrite{RLE: req.RLE, Data: req.Data}
+
+		data := req.Data
+		if req.RLE {
+			var err error
+			if data, err = fppclient.DecodeRLE(data); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		if !m.locked {
+			copy(m.data, data)
+		}
+
+		writeOK(w)
+	}))
+
+	mux.HandleFunc("PUT /api/overlays/model/{name}/fill", s.withModel(func(w http.ResponseWriter, r *http.Request, m *model) {
+		var req struct {
+			RGB []int `json:"RGB"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.RGB) != 3 {
+			writeError(w, http.StatusBadRequest, "Invalid request")
+			return
+		}
+
+		if !m.locked {
+			m.fill(req.RGB[0], req.RGB[1], req.RGB[2])
+		}
+
+		writeOK(w)
+	}))
+
+	mux.HandleFunc("PUT /api/overlays/model/{name}/pixel", s.withModel(func(w http.ResponseWriter, r *http.Request, m *model) {
+		var req struct {
+			X   int   `json:"X"`
+			Y   int   `json:"Y"`
+			RGB []int `json:"RGB"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.RGB) != 3 {
+			writeError(w, http.StatusBadRequest, "Invalid request")
+			return
+		}
+
+		i := (req.Y*m.Width + req.X) * 3
+		if req.X < 0 || req.Y < 0 || req.X >= m.Width || i+2 >= len(m.data) {
+			writeError(w, http.StatusBadRequest, "Pixel out of range")
+			return
+		}
+
+		if !m.locked {
+			copy(m.data[i:], req.RGB)
+		}
+
+		writeOK(w)
+	}))
+
+	mux.HandleFunc("PUT /api/overlays/model/{name}/state", s.withModel(func(w http.ResponseWriter, r *http.Request, m *model) {
+		var req struct {
+			State fppclient.ModelState `json:"State"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON")
+			return
+		}
+
+		m.IsActive = req.State
+		writeOK(w)
+	}))
+
+	mux.HandleFunc("PUT /api/overlays/model/{name}/text", s.withModel(func(w http.ResponseWriter, r *http.Request, m *model) {
+		var text Text
+		if err := json.NewDecoder(r.Body).Decode(&text); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid JSON")
+			return
+		}
+
+		if !s.fonts.Contains(text.Font) {
+			writeError(w, http.StatusBadRequest, "Unknown font")
+			return
+		}
+
+		m.texts = append(m.texts, text)
+		writeOK(w)
+	}))
+
+	mux.HandleFunc("GET /api/overlays/model/{name}/clear", s.withModel(func(w http.ResponseWriter, r *http.Request, m *model) {
+		if !m.locked {
+			m.fill(0, 0, 0)
+		}
+
+		writeOK(w)
+	}))
+}
+
+// withModel looks up the model named in the path and calls fn with the lock
+// held.
+func (s *Server) withModel(fn func(w http.ResponseWriter, r *http.Request, m *model)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		m, ok := s.models[pathValue(r, "name")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Model not found")
+			return
+		}
+
+		fn(w, r, m)
+	}
+}
+
+// AddModel adds an overlay model to the fake, its pixels start out black.
+// The model's size comes from ChannelCount, or Width and Height if unset.
+func (s *Server) AddModel(m fppclient.Model) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	size := m.ChannelCount
+	if size == 0 {
+		size = m.Width * m.Height * 3
+	}
+
+	if _, ok := s.models[m.Name]; !ok {
+		s.order = append(s.order, m.Name)
+	}
+
+	s.models[m.Name] = &model{Model: m, data: make([]int, size)}
+}
+
+// Model returns the named model, including its current state.
+func (s *Server) Model(name string) (fppclient.Model, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.models[name]
+	if !ok {
+		return fppclient.Model{}, false
+	}
+
+	return m.Model, true
+}
+
+// ModelData returns a copy of the named model's RGB values.
+func (s *Server) ModelData(name string) ([]int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.models[name]
+	if !ok {
+		return nil, false
+	}
+
+	return append([]int(nil), m.data...), true
+}
+
+// LastDataWrite returns the last data written to the named model, as it was
+// sent, so tests can tell run length encoded writes from raw ones.
+func (s *Server) LastDataWrite(name string) (DataWrite, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.models[name]
+	if !ok || m.lastWrite == nil {
+		return DataWrite{}, false
+	}
+
+	return DataWrite{RLE: m.lastWrite.RLE, Data: append([]int(nil), m.lastWrite.Data...)}, true
+}
+
+// LockModel sets whether the named model is locked, FPP acknowledges but
+// ignores writes to a locked model.
+func (s *Server) LockModel(name string, locked bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.models[name]; ok {
+		m.locked = locked
+	}
+}
+
+// Texts returns the text that has been displayed on the named model.
+func (s *Server) Texts(name string) []Text {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.models[name]; ok {
+		return append([]Text(nil), m.texts...)
+	}
+
+	return nil
+}
+
+// SetEffects sets the effects served from /api/overlays/effects.
+func (s *Server) SetEffects(effects ...fppclient.OverlayEffect) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.effects = append(fppclient.OverlayEffects{}, effects...)
+}
+
+// SetFonts sets the fonts served from /api/overlays/fonts.
+func (s *Server) SetFonts(fonts ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fonts = append(fppclient.Fonts{}, fonts...)
+}
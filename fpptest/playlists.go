@@ -0,0 +1,143 @@
+package fpptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/freman/fppclient"
+)
+
+func (s *Server) routePlaylists(mux *router) {
+	mux.HandleFunc("GET /api/playlists", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		names := []string{}
+		for name := range s.playlists {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		writeJSON(w, names)
+	})
+
+	mux.HandleFunc("POST /api/playlists", func(w http.ResponseWriter, r *http.Request) {
+		s.savePlaylist(w, r, "")
+	})
+
+	mux.HandleFunc("POST /api/playlist/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.savePlaylist(w, r, pathValue(r, "name"))
+	})
+
+	mux.HandleFunc("GET /api/playlist/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		playlist, ok := s.playlists[pathValue(r, "name")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Playlist not found")
+			return
+		}
+
+		writeJSON(w, playlist)
+	})
+
+	mux.HandleFunc("DELETE /api/playlist/{name}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		name := pathValue(r, "name")
+		if _, ok := s.playlists[name]; !ok {
+			writeError(w, http.StatusNotFound, "Playlist not found")
+			return
+		}
+
+		delete(s.playlists, name)
+		writeOK(w)
+	})
+
+	mux.HandleFunc("GET /api/playlist/{name}/start/{repeat}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		name := pathValue(r, "name")
+		if _, ok := s.playlists[name]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Playlist %q not found", name))
+			return
+		}
+
+		s.setPlaying(name, 1)
+		writeOK(w)
+	})
+
+	stop := func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.setIdle()
+		writeOK(w)
+	}
+
+	mux.HandleFunc("GET /api/playlists/stop", stop)
+	mux.HandleFunc("GET /api/playlists/stopgracefully", stop)
+	mux.HandleFunc("GET /api/playlists/stopgracefullyafterloop", stop)
+
+	mux.HandleFunc("GET /api/playlists/pause", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.setPaused(true)
+		writeOK(w)
+	})
+
+	mux.HandleFunc("GET /api/playlists/resume", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.setPaused(false)
+		writeOK(w)
+	})
+}
+
+// savePlaylist stores the playlist in the body of r, under name if given.
+func (s *Server) savePlaylist(w http.ResponseWriter, r *http.Request, name string) {
+	var playlist fppclient.Playlist
+	if err := json.NewDecoder(r.Body).Decode(&playlist); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if name != "" {
+		playlist.Name = name
+	}
+
+	if playlist.Name == "" {
+		writeError(w, http.StatusBadRequest, "Playlist name is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.playlists[playlist.Name] = playlist
+	writeOK(w)
+}
+
+// AddPlaylist stores a playlist on the fake, replacing any of the same name.
+func (s *Server) AddPlaylist(playlist fppclient.Playlist) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.playlists[playlist.Name] = playlist
+}
+
+// Playlist returns the named playlist as the fake has it.
+func (s *Server) Playlist(name string) (fppclient.Playlist, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	playlist, ok := s.playlists[name]
+	return playlist, ok
+}
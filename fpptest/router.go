@@ -0,0 +1,111 @@
+package fpptest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// router dispatches requests on patterns like "GET /api/playlist/{name}",
+// where a final "{name...}" matches the rest of the path.
+type router struct {
+	routes []route
+}
+
+type route struct {
+	method   string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+type pathValuesKey struct{}
+
+func newRouter() *router {
+	return &router{}
+}
+
+func (rt *router) HandleFunc(pattern string, handler http.HandlerFunc) {
+	method, p, _ := strings.Cut(pattern, " ")
+
+	rt.routes = append(rt.routes, route{
+		method:   method,
+		segments: strings.Split(strings.TrimPrefix(p, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), "/"), "/")
+
+	var allowed []string
+
+	for _, route := range rt.routes {
+		values, ok := route.match(path)
+		if !ok {
+			continue
+		}
+
+		if route.method != r.Method && !(route.method == http.MethodGet && r.Method == http.MethodHead) {
+			allowed = append(allowed, route.method)
+			continue
+		}
+
+		route.handler(w, r.WithContext(context.WithValue(r.Context(), pathValuesKey{}, values)))
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+func (rt route) match(path []string) (map[string]string, bool) {
+	values := map[string]string{}
+
+	for i, seg := range rt.segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "...}") {
+			if i >= len(path) {
+				return nil, false
+			}
+
+			v, err := url.PathUnescape(strings.Join(path[i:], "/"))
+			if err != nil {
+				return nil, false
+			}
+
+			values[strings.TrimSuffix(seg[1:], "...}")] = v
+			return values, true
+		}
+
+		if i >= len(path) {
+			return nil, false
+		}
+
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			v, err := url.PathUnescape(path[i])
+			if err != nil || v == "" {
+				return nil, false
+			}
+
+			values[seg[1:len(seg)-1]] = v
+			continue
+		}
+
+		if seg != path[i] {
+			return nil, false
+		}
+	}
+
+	return values, len(path) == len(rt.segments)
+}
+
+// pathValue returns the value of the named wildcard in the route r matched.
+func pathValue(r *http.Request, name string) string {
+	values, _ := r.Context().Value(pathValuesKey{}).(map[string]string)
+	return values[name]
+}
@@ -0,0 +1,265 @@
+// Package fpptest provides an in-process fake FPP for testing code built on
+// fppclient without a player on the network.
+//
+// The fake keeps its state in memory and implements enough of the REST API
+// for the client: status, schedule, playlists, files, overlays, commands and
+// config files. Failures and latency can be injected per endpoint.
+package fpptest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/freman/fppclient"
+)
+
+// A Failure makes matching requests fail instead of reaching the fake.
+type Failure struct {
+	// Method and Path select the requests to fail, an empty Method matches
+	// any method and Path may be a path.Match pattern.
+	Method string
+	Path   string
+
+	// StatusCode and Body make up the response, StatusCode defaults to 500.
+	StatusCode int
+	Body       string
+
+	// Times limits how many requests fail, zero fails them all.
+	Times int
+}
+
+func (f *Failure) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+
+	ok, _ := path.Match(f.Path, r.URL.Path)
+	return ok
+}
+
+// Server is a fake FPP, it's safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	latency  time.Duration
+	failures []*Failure
+	requests []string
+	inFlight int
+	peak     int
+
+	status   map[string]interface{}
+	schedule json.RawMessage
+
+	commandDefs fppclient.CommandDefinitions
+	commands    []fppclient.Command
+
+	playlists map[string]fppclient.Playlist
+	files     map[string]map[string]file
+	configs   map[string]json.RawMessage
+
+	models  map[string]*model
+	order   []string
+	fonts   fppclient.Fonts
+	effects fppclient.OverlayEffects
+}
+
+// NewServer starts a fake FPP that is idle and has nothing on it, call
+// Close when done.
+func NewServer() *Server {
+	s := Server{
+		status:    defaultStatus(),
+		schedule:  json.RawMessage("[]"),
+		playlists: map[string]fppclient.Playlist{},
+		files:     map[string]map[string]file{},
+		configs:   map[string]json.RawMessage{},
+		models:    map[string]*model{},
+		fonts:     fppclient.Fonts{},
+		effects:   fppclient.OverlayEffects{},
+	}
+
+	mux := newRouter()
+	s.routeStatus(mux)
+	s.routeCommands(mux)
+	s.routePlaylists(mux)
+	s.routeFiles(mux)
+	s.routeOverlays(mux)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+
+	return &s
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		latency := s.latency
+		failure := s.failure(r)
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		if s.inFlight++; s.inFlight > s.peak {
+			s.peak = s.inFlight
+		}
+		s.mu.Unlock()
+
+		defer func() {
+			s.mu.Lock()
+			s.inFlight--
+			s.mu.Unlock()
+		}()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if failure != nil {
+			w.WriteHeader(failure.StatusCode)
+			w.Write([]byte(failure.Body)) //nolint:errcheck
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// failure returns the first injected failure matching r, using up one of
+// its Times.
+func (s *Server) failure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if !f.matches(r) {
+			continue
+		}
+
+		res := *f
+		if f.Times > 0 {
+			if f.Times--; f.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+
+		if res.StatusCode == 0 {
+			res.StatusCode = http.StatusInternalServerError
+		}
+
+		return &res
+	}
+
+	return nil
+}
+
+// Fail injects a failure, it takes precedence over those added before it.
+func (s *Server) Fail(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append([]*Failure{&f}, s.failures...)
+}
+
+// ClearFailures removes all injected failures.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = nil
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// Requests returns the "METHOD /path" of every request received so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// PeakInFlight returns the most requests that have been handled at once,
+// use SetLatency to give them time to overlap.
+func (s *Server) PeakInFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.peak
+}
+
+// Commands returns the commands that have been posted so far.
+func (s *Server) Commands() []fppclient.Command {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]fppclient.Command(nil), s.commands...)
+}
+
+// SetCommands sets the definitions served from /api/commands, once set
+// unknown commands are refused.
+func (s *Server) SetCommands(defs fppclient.CommandDefinitions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.commandDefs = defs
+}
+
+// SetConfig stores v as JSON to be served as the named config file.
+func (s *Server) SetConfig(name string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.configs[name] = b
+
+	return nil
+}
+
+// UpdateStatus calls fn with the JSON object served from /api/fppd/status,
+// which fn may modify.
+func (s *Server) UpdateStatus(fn func(status map[string]interface{})) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(s.status)
+}
+
+// Status returns the status as the client sees it.
+func (s *Server) Status() fppclient.FPPDStatus {
+	s.mu.Lock()
+	b, _ := json.Marshal(s.status) //nolint:errcheck // it's all plain values.
+	s.mu.Unlock()
+
+	var status fppclient.FPPDStatus
+	json.Unmarshal(b, &status) //nolint:errcheck // as long as UpdateStatus was used sensibly.
+
+	return status
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+func writeOK(w http.ResponseWriter) {
+	writeJSON(w, fppclient.Status{Status: "OK"})
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(fppclient.Status{Status: "ERROR", Message: message}) //nolint:errcheck
+}
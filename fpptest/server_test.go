@@ -0,0 +1,93 @@
+package fpptest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestFailureInjection(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 2, Height: 1})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+
+	srv.Fail(fpptest.Failure{Path: "/api/overlays/model/*/fill", Times: 1})
+	srv.Fail(fpptest.Failure{
+		Method:     http.MethodPut,
+		Path:       "/api/overlays/model/Matrix/pixel",
+		StatusCode: http.StatusConflict,
+		Body:       `{"Status":"ERROR","message":"Model is locked"}`,
+	})
+
+	require.ErrorIs(t, c.FillOverlaysModel(ctx, "Matrix", 1, 2, 3), fppclient.ErrServer)
+	require.NoError(t, c.FillOverlaysModel(ctx, "Matrix", 1, 2, 3))
+
+	var bodyErr *fppclient.BodyError
+	require.ErrorAs(t, c.SetOverlaysModelPixel(ctx, "Matrix", 0, 0, 1, 2, 3), &bodyErr)
+	require.Equal(t, http.StatusConflict, bodyErr.StatusCode)
+	require.ErrorIs(t, bodyErr, fppclient.ErrModelLocked)
+
+	srv.ClearFailures()
+	require.NoError(t, c.SetOverlaysModelPixel(ctx, "Matrix", 0, 0, 1, 2, 3))
+
+	require.Equal(t, []string{
+		"PUT /api/overlays/model/Matrix/fill",
+		"PUT /api/overlays/model/Matrix/fill",
+		"PUT /api/overlays/model/Matrix/pixel",
+		"PUT /api/overlays/model/Matrix/pixel",
+	}, srv.Requests())
+}
+
+func TestLatency(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	srv.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = c.GetFPPDStatus(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	srv.SetLatency(0)
+
+	_, err = c.GetFPPDStatus(context.Background())
+	require.NoError(t, err)
+}
+
+func TestCommandDefinitions(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.SetCommands(fppclient.CommandDefinitions{{Name: "Volume Set"}})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+
+	_, err = c.PostCommand(ctx, fppclient.CommandVolumeSet(40))
+	require.NoError(t, err)
+	require.Equal(t, 40, srv.Status().Volume)
+
+	_, err = c.PostCommand(ctx, fppclient.CommandAllLightsOff())
+	require.ErrorIs(t, err, fppclient.ErrNotFound)
+
+	require.Equal(t, []fppclient.Command{fppclient.CommandVolumeSet(40)}, srv.Commands())
+}
@@ -0,0 +1,219 @@
+package fpptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/freman/fppclient"
+)
+
+// The values of the status field, as fppd reports them.
+const (
+	statusIdle    = 0
+	statusPlaying = 1
+	statusPaused  = 5
+)
+
+func defaultStatus() map[string]interface{} {
+	return map[string]interface{}{
+		"MQTT":     map[string]interface{}{"configured": false, "connected": false},
+		"bridging": false,
+		"current_playlist": map[string]interface{}{
+			"count":       "0",
+			"description": "",
+			"index":       "0",
+			"playlist":    "",
+			"type":        "",
+		},
+		"current_sequence": "",
+		"current_song":     "",
+		"fppd":             "running",
+		"mode":             2,
+		"mode_name":        "player",
+		"multisync":        false,
+		"next_playlist": map[string]interface{}{
+			"playlist":   "No playlist scheduled.",
+			"start_time": "",
+		},
+		"repeat_mode": "0",
+		"scheduler": map[string]interface{}{
+			"enabled": 1,
+			"nextPlaylist": map[string]interface{}{
+				"playlistName":          "No playlist scheduled.",
+				"scheduledStartTime":    0,
+				"scheduledStartTimeStr": "",
+			},
+			"status": "idle",
+		},
+		"seconds_played":    "0",
+		"seconds_remaining": "0",
+		"sensors":           []interface{}{},
+		"status":            statusIdle,
+		"status_name":       "idle",
+		"time_elapsed":      "00:00",
+		"time_remaining":    "00:00",
+		"uuid":              "M1-FPPTEST",
+		"volume":            70,
+		"warnings":          []interface{}{},
+	}
+}
+
+func (s *Server) routeStatus(mux *router) {
+	mux.HandleFunc("GET /api/fppd/status", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		writeJSON(w, s.status)
+	})
+
+	mux.HandleFunc("GET /api/fppd/schedule", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"Status":   "OK",
+			"respCode": 200,
+			"schedule": map[string]interface{}{
+				"enabled": 1,
+				"entries": []interface{}{},
+				"items":   []interface{}{},
+			},
+		})
+	})
+
+	mux.HandleFunc("GET /api/schedule", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(s.schedule) //nolint:errcheck
+	})
+
+	mux.HandleFunc("POST /api/schedule", func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil || !json.Valid(b) {
+			writeError(w, http.StatusBadRequest, "Invalid JSON")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.schedule = b
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(s.schedule) //nolint:errcheck
+	})
+
+	mux.HandleFunc("POST /api/schedule/reload", func(w http.ResponseWriter, r *http.Request) {
+		writeOK(w)
+	})
+
+	mux.HandleFunc("GET /api/configfile/{name...}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		b, ok := s.configs[pathValue(r, "name")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "File not found")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b) //nolint:errcheck
+	})
+
+	mux.HandleFunc("GET /api/plugin", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []string{})
+	})
+}
+
+// SetSchedule replaces the schedule served from /api/schedule.
+func (s *Server) SetSchedule(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schedule = b
+
+	return nil
+}
+
+// setPlaying updates the status to reflect the named playlist running, the
+// caller must hold the lock.
+func (s *Server) setPlaying(name string, index int) {
+	playlist := s.playlists[name]
+
+	var sequence string
+	if index > 0 && index <= len(playlist.MainPlaylist) {
+		switch e := playlist.MainPlaylist[index-1].(type) {
+		case *fppclient.SequenceEntry:
+			sequence = e.SequenceName
+		case *fppclient.BothEntry:
+			sequence = e.SequenceName
+		}
+	}
+
+	s.status["status"] = statusPlaying
+	s.status["status_name"] = "playing"
+	s.status["current_sequence"] = sequence
+	s.status["current_playlist"] = map[string]interface{}{
+		"count":       strconv.Itoa(len(playlist.MainPlaylist)),
+		"description": playlist.Desc,
+		"index":       strconv.Itoa(index),
+		"playlist":    name,
+		"type":        "sequence",
+	}
+}
+
+// setIdle is setPlaying's opposite, the caller must hold the lock.
+func (s *Server) setIdle() {
+	s.status["status"] = statusIdle
+	s.status["status_name"] = "idle"
+	s.status["current_sequence"] = ""
+	s.status["current_playlist"] = map[string]interface{}{
+		"count":       "0",
+		"description": "",
+		"index":       "0",
+		"playlist":    "",
+		"type":        "",
+	}
+}
+
+// advance moves the running playlist by delta entries, the caller must hold
+// the lock.
+func (s *Server) advance(delta int) {
+	current, _ := s.status["current_playlist"].(map[string]interface{})
+	name, _ := current["playlist"].(string)
+	if name == "" {
+		return
+	}
+
+	index, _ := strconv.Atoi(fmt.Sprint(current["index"]))
+	count := len(s.playlists[name].MainPlaylist)
+
+	if index += delta; index < 1 {
+		index = 1
+	} else if index > count {
+		index = count
+	}
+
+	s.setPlaying(name, index)
+}
+
+// setPaused pauses or resumes a running playlist, the caller must hold the
+// lock.
+func (s *Server) setPaused(paused bool) {
+	switch {
+	case paused && s.status["status"] == statusPlaying:
+		s.status["status"] = statusPaused
+		s.status["status_name"] = "paused"
+	case !paused && s.status["status"] == statusPaused:
+		s.status["status"] = statusPlaying
+		s.status["status_name"] = "playing"
+	}
+}
@@ -0,0 +1,186 @@
+// Package fseq reads and writes the FSEQ sequence files used by FPP.
+package fseq
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrBadMagic           = errors.New("fseq: not an fseq file")
+	ErrUnsupportedVersion = errors.New("fseq: unsupported version")
+	ErrFrameOutOfRange    = errors.New("fseq: frame out of range")
+)
+
+// Compression is the block compression used for the channel data.
+type Compression uint8
+
+const (
+	CompressionNone Compression = 0
+	CompressionZstd Compression = 1
+	CompressionZlib Compression = 2
+)
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionZstd:
+		return "zstd"
+	case CompressionZlib:
+		return "zlib"
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(c))
+}
+
+// Block describes a compressed block of frames, Length is the compressed
+// size in bytes.
+type Block struct {
+	FirstFrame uint32
+	Length     uint32
+}
+
+// SparseRange is a range of channels stored in the file, StartChannel is
+// zero based.
+type SparseRange struct {
+	StartChannel uint32
+	ChannelCount uint32
+}
+
+// VariableHeader is one of the optional headers, Code is two characters,
+// e.g. mf for the media file or sp for the sequence producer.
+type VariableHeader struct {
+	Code string
+	Data []byte
+}
+
+type Header struct {
+	MajorVersion      uint8
+	MinorVersion      uint8
+	ChannelDataOffset uint16
+
+	// ChannelCount is the number of channels stored per frame, which when
+	// there are sparse ranges is their total.
+	ChannelCount uint32
+	FrameCount   uint32
+	StepTimeMS   uint8
+
+	// Gamma and ColorEncoding are only present in version 1 files.
+	Gamma         uint8
+	ColorEncoding uint8
+
+	Compression     Compression
+	UniqueID        uint64
+	Blocks          []Block
+	SparseRanges    []SparseRange
+	VariableHeaders []VariableHeader
+}
+
+func (h Header) StepTime() time.Duration {
+	return time.Duration(h.StepTimeMS) * time.Millisecond
+}
+
+func (h Header) Duration() time.Duration {
+	return time.Duration(h.FrameCount) * h.StepTime()
+}
+
+// ChannelRange returns the zero based start and count of the channels the
+// file covers.
+func (h Header) ChannelRange() (start, count uint32) {
+	if len(h.SparseRanges) == 0 {
+		return 0, h.ChannelCount
+	}
+
+	start = h.SparseRanges[0].StartChannel
+	end := start
+
+	for _, r := range h.SparseRanges {
+		if r.StartChannel < start {
+			start = r.StartChannel
+		}
+
+		if e := r.StartChannel + r.ChannelCount; e > end {
+			end = e
+		}
+	}
+
+	return start, end - start
+}
+
+// Variable returns the data of the first variable header with the code.
+func (h Header) Variable(code string) ([]byte, bool) {
+	for _, v := range h.VariableHeaders {
+		if v.Code == code {
+			return v.Data, true
+		}
+	}
+
+	return nil, false
+}
+
+// MediaFile is the media file the sequence was created for, if any.
+func (h Header) MediaFile() string {
+	return h.variableString("mf")
+}
+
+// Producer is the software that created the sequence, if known.
+func (h Header) Producer() string {
+	return h.variableString("sp")
+}
+
+// variableString returns a variable header as a string, they're usually
+// null terminated.
+func (h Header) variableString(code string) string {
+	b, _ := h.Variable(code)
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+
+	return string(b)
+}
+
+const (
+	fixedHeaderLen = 32
+	v1HeaderLen    = 28
+	blockLen       = 8
+	sparseRangeLen = 6
+)
+
+var magic = [4]byte{'P', 'S', 'E', 'Q'}
+
+func uint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
+
+func putUint24(b []byte, v uint32) {
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+}
+
+// parseVariableHeaders decodes the variable headers in b, stopping at the
+// first one that doesn't fit.
+func parseVariableHeaders(b []byte) []VariableHeader {
+	var headers []VariableHeader
+
+	for len(b) >= 4 {
+		length := int(binary.LittleEndian.Uint16(b))
+		if length < 4 || length > len(b) {
+			break
+		}
+
+		headers = append(headers, VariableHeader{
+			Code: string(b[2:4]),
+			Data: append([]byte(nil), b[4:length]...),
+		})
+
+		b = b[length:]
+	}
+
+	return headers
+}
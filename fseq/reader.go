@@ -0,0 +1,304 @@
+package fseq
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Reader gives random access to the frames of an FSEQ file.
+type Reader struct {
+	Header
+
+	r io.ReaderAt
+
+	mu         sync.Mutex
+	blockIndex int
+	blockData  []byte
+}
+
+// NewReader parses the headers from r.
+func NewReader(r io.ReaderAt) (*Reader, error) {
+	fixed := make([]byte, fixedHeaderLen)
+	if _, err := r.ReadAt(fixed, 0); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("fseq: unable to read header: %w", err)
+	}
+
+	if !bytes.Equal(fixed[:4], magic[:]) && string(fixed[:4]) != "FSEQ" {
+		return nil, ErrBadMagic
+	}
+
+	h := Header{
+		ChannelDataOffset: binary.LittleEndian.Uint16(fixed[4:]),
+		MinorVersion:      fixed[6],
+		MajorVersion:      fixed[7],
+		ChannelCount:      binary.LittleEndian.Uint32(fixed[10:]),
+		FrameCount:        binary.LittleEndian.Uint32(fixed[14:]),
+		StepTimeMS:        fixed[18],
+	}
+
+	var err error
+
+	switch h.MajorVersion {
+	case 1:
+		err = h.readV1(r, fixed)
+	case 2:
+		err = h.readV2(r, fixed)
+	default:
+		return nil, fmt.Errorf("%w: %d.%d", ErrUnsupportedVersion, h.MajorVersion, h.MinorVersion)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{Header: h, r: r, blockIndex: -1}, nil
+}
+
+func (h *Header) readV1(r io.ReaderAt, fixed []byte) error {
+	h.Gamma = fixed[24]
+	h.ColorEncoding = fixed[25]
+
+	headerLen := int(binary.LittleEndian.Uint16(fixed[8:]))
+	if headerLen < v1HeaderLen {
+		headerLen = v1HeaderLen
+	}
+
+	if int(h.ChannelDataOffset) <= headerLen {
+		return nil
+	}
+
+	vars := make([]byte, int(h.ChannelDataOffset)-headerLen)
+	if _, err := r.ReadAt(vars, int64(headerLen)); err != nil {
+		return fmt.Errorf("fseq: unable to read variable headers: %w", err)
+	}
+
+	h.VariableHeaders = parseVariableHeaders(vars)
+
+	return nil
+}
+
+func (h *Header) readV2(r io.ReaderAt, fixed []byte) error {
+	h.Compression = Compression(fixed[20] & 0x0f)
+	blockCount := int(fixed[21]) | int(fixed[20]&0xf0)<<4
+	sparseCount := int(fixed[22])
+	h.UniqueID = binary.LittleEndian.Uint64(fixed[24:])
+
+	if h.ChannelDataOffset < fixedHeaderLen {
+		return fmt.Errorf("fseq: channel data offset %d inside the %d byte header", h.ChannelDataOffset, fixedHeaderLen)
+	}
+
+	varOffset := int(binary.LittleEndian.Uint16(fixed[8:]))
+	if varOffset > int(h.ChannelDataOffset) {
+		return fmt.Errorf("fseq: variable header offset %d beyond channel data %d", varOffset, h.ChannelDataOffset)
+	}
+
+	rest := make([]byte, int(h.ChannelDataOffset)-fixedHeaderLen)
+	if _, err := r.ReadAt(rest, fixedHeaderLen); err != nil {
+		return fmt.Errorf("fseq: unable to read header: %w", err)
+	}
+
+	tables := blockCount*blockLen + sparseCount*sparseRangeLen
+	if tables > len(rest) {
+		return fmt.Errorf("fseq: header too short for %d blocks and %d sparse ranges", blockCount, sparseCount)
+	}
+
+	for i := 0; i < blockCount; i++ {
+		b := rest[i*blockLen:]
+		block := Block{
+			FirstFrame: binary.LittleEndian.Uint32(b),
+			Length:     binary.LittleEndian.Uint32(b[4:]),
+		}
+
+		// Writers pad the block table with empty entries.
+		if block.Length == 0 {
+			continue
+		}
+
+		h.Blocks = append(h.Blocks, block)
+	}
+
+	for i := 0; i < sparseCount; i++ {
+		b := rest[blockCount*blockLen+i*sparseRangeLen:]
+		h.SparseRanges = append(h.SparseRanges, SparseRange{
+			StartChannel: uint24(b),
+			ChannelCount: uint24(b[3:]),
+		})
+	}
+
+	if varOffset >= fixedHeaderLen {
+		h.VariableHeaders = parseVariableHeaders(rest[varOffset-fixedHeaderLen:])
+	}
+
+	return nil
+}
+
+// Open opens the named file, the returned closer closes the underlying file.
+func Open(name string) (*Reader, io.Closer, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return r, f, nil
+}
+
+// Frame returns a copy of the stored channel data for frame n.
+func (r *Reader) Frame(n int) ([]byte, error) {
+	buf := make([]byte, r.ChannelCount)
+	if err := r.ReadFrame(n, buf); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// ReadFrame reads the stored channel data for frame n into buf, which must
+// be at least ChannelCount bytes.
+func (r *Reader) ReadFrame(n int, buf []byte) error {
+	if n < 0 || uint32(n) >= r.FrameCount {
+		return fmt.Errorf("%w: %d of %d", ErrFrameOutOfRange, n, r.FrameCount)
+	}
+
+	if len(buf) < int(r.ChannelCount) {
+		return fmt.Errorf("fseq: buffer of %d bytes too small for %d channels", len(buf), r.ChannelCount)
+	}
+
+	buf = buf[:r.ChannelCount]
+	frameLen := int64(r.ChannelCount)
+
+	if r.Compression == CompressionNone || len(r.Blocks) == 0 {
+		if _, err := r.r.ReadAt(buf, int64(r.ChannelDataOffset)+int64(n)*frameLen); err != nil {
+			return fmt.Errorf("fseq: unable to read frame %d: %w", n, err)
+		}
+
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx := sort.Search(len(r.Blocks), func(i int) bool {
+		return r.Blocks[i].FirstFrame > uint32(n)
+	}) - 1
+
+	if idx < 0 {
+		return fmt.Errorf("%w: %d not in any block", ErrFrameOutOfRange, n)
+	}
+
+	if err := r.loadBlock(idx); err != nil {
+		return err
+	}
+
+	offset := int64(uint32(n)-r.Blocks[idx].FirstFrame) * frameLen
+	if offset+frameLen > int64(len(r.blockData)) {
+		return fmt.Errorf("fseq: frame %d missing from block %d", n, idx)
+	}
+
+	copy(buf, r.blockData[offset:offset+frameLen])
+
+	return nil
+}
+
+// loadBlock decompresses block idx, keeping the most recent block cached as
+// frames are usually read in order.
+func (r *Reader) loadBlock(idx int) error {
+	if r.blockIndex == idx {
+		return nil
+	}
+
+	offset := int64(r.ChannelDataOffset)
+	for _, b := range r.Blocks[:idx] {
+		offset += int64(b.Length)
+	}
+
+	compressed := make([]byte, r.Blocks[idx].Length)
+	if _, err := r.r.ReadAt(compressed, offset); err != nil {
+		return fmt.Errorf("fseq: unable to read block %d: %w", idx, err)
+	}
+
+	data, err := decompress(r.Compression, compressed)
+	if err != nil {
+		return fmt.Errorf("fseq: unable to decompress block %d: %w", idx, err)
+	}
+
+	r.blockIndex = idx
+	r.blockData = data
+
+	return nil
+}
+
+var (
+	zstdOnce    sync.Once
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// sharedZstdDecoder returns the decoder shared by every Reader, it's made
+// the first time a zstd block is read.
+func sharedZstdDecoder() (*zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdDecoder, zstdErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+
+	return zstdDecoder, zstdErr
+}
+
+func decompress(c Compression, b []byte) ([]byte, error) {
+	switch c {
+	case CompressionZstd:
+		dec, err := sharedZstdDecoder()
+		if err != nil {
+			return nil, err
+		}
+
+		return dec.DecodeAll(b, nil)
+	case CompressionZlib:
+		zr, err := zlib.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+
+		defer zr.Close()
+
+		return io.ReadAll(zr)
+	}
+
+	return nil, fmt.Errorf("unsupported compression %s", c)
+}
+
+// ExpandFrame copies a stored frame into dst at the absolute channel
+// positions described by the sparse ranges, dst is indexed from channel 0.
+func (h Header) ExpandFrame(frame, dst []byte) {
+	if len(h.SparseRanges) == 0 {
+		copy(dst, frame)
+		return
+	}
+
+	for _, sr := range h.SparseRanges {
+		if len(frame) == 0 || int(sr.StartChannel) >= len(dst) {
+			break
+		}
+
+		count := int(sr.ChannelCount)
+		if count > len(frame) {
+			count = len(frame)
+		}
+
+		n := copy(dst[sr.StartChannel:], frame[:count])
+		frame = frame[n:]
+	}
+}
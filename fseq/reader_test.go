@@ -0,0 +1,224 @@
+package fseq_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient/fseq"
+)
+
+const (
+	testChannels = 6
+	testFrames   = 5
+)
+
+func testFrame(n int) []byte {
+	frame := make([]byte, testChannels)
+	for i := range frame {
+		frame[i] = byte(n*testChannels + i)
+	}
+
+	return frame
+}
+
+func varHeader(code, data string) []byte {
+	b := make([]byte, 4, 4+len(data)+1)
+	binary.LittleEndian.PutUint16(b, uint16(4+len(data)+1))
+	copy(b[2:], code)
+
+	return append(append(b, data...), 0)
+}
+
+// buildV2 lays out a version 2 file by hand, blocks holds the frame counts
+// of each compressed block.
+func buildV2(t *testing.T, compression fseq.Compression, blocks []int, sparse [][2]uint32) []byte {
+	var data []byte
+	var table []byte
+
+	if compression == fseq.CompressionNone {
+		for n := 0; n < testFrames; n++ {
+			data = append(data, testFrame(n)...)
+		}
+	} else {
+		first := 0
+		for _, count := range blocks {
+			var raw []byte
+			for n := first; n < first+count; n++ {
+				raw = append(raw, testFrame(n)...)
+			}
+
+			var compressed []byte
+			if compression == fseq.CompressionZstd {
+				enc, err := zstd.NewWriter(nil)
+				require.NoError(t, err)
+				compressed = enc.EncodeAll(raw, nil)
+			} else {
+				var buf bytes.Buffer
+				zw := zlib.NewWriter(&buf)
+				_, err := zw.Write(raw)
+				require.NoError(t, err)
+				require.NoError(t, zw.Close())
+				compressed = buf.Bytes()
+			}
+
+			entry := make([]byte, 8)
+			binary.LittleEndian.PutUint32(entry, uint32(first))
+			binary.LittleEndian.PutUint32(entry[4:], uint32(len(compressed)))
+			table = append(table, entry...)
+			data = append(data, compressed...)
+			first += count
+		}
+
+		// Padding entry, as written by xLights.
+		table = append(table, make([]byte, 8)...)
+	}
+
+	for _, sr := range sparse {
+		entry := make([]byte, 6)
+		entry[0], entry[1], entry[2] = byte(sr[0]), byte(sr[0]>>8), byte(sr[0]>>16)
+		entry[3], entry[4], entry[5] = byte(sr[1]), byte(sr[1]>>8), byte(sr[1]>>16)
+		table = append(table, entry...)
+	}
+
+	vars := append(varHeader("mf", "song.mp3"), varHeader("sp", "test")...)
+	varOffset := 32 + len(table)
+	dataOffset := varOffset + len(vars)
+
+	header := make([]byte, 32)
+	copy(header, "PSEQ")
+	binary.LittleEndian.PutUint16(header[4:], uint16(dataOffset))
+	header[6], header[7] = 0, 2
+	binary.LittleEndian.PutUint16(header[8:], uint16(varOffset))
+	binary.LittleEndian.PutUint32(header[10:], testChannels)
+	binary.LittleEndian.PutUint32(header[14:], testFrames)
+	header[18] = 25
+	header[20] = byte(compression)
+	if compression != fseq.CompressionNone {
+		header[21] = byte(len(blocks) + 1)
+	}
+	header[22] = byte(len(sparse))
+	binary.LittleEndian.PutUint64(header[24:], 42)
+
+	return append(append(append(header, table...), vars...), data...)
+}
+
+func TestReaderV2(t *testing.T) {
+	checks := []struct {
+		Name        string
+		Compression fseq.Compression
+		Blocks      []int
+	}{
+		{"none", fseq.CompressionNone, nil},
+		{"zstd", fseq.CompressionZstd, []int{1, 3, 1}},
+		{"zlib", fseq.CompressionZlib, []int{2, 3}},
+	}
+
+	for _, check := range checks {
+		t.Run(check.Name, func(t *testing.T) {
+			r, err := fseq.NewReader(bytes.NewReader(buildV2(t, check.Compression, check.Blocks, nil)))
+			require.NoError(t, err)
+
+			require.Equal(t, uint8(2), r.MajorVersion)
+			require.Equal(t, check.Compression, r.Compression)
+			require.Len(t, r.Blocks, len(check.Blocks))
+			require.Equal(t, uint64(42), r.UniqueID)
+			require.Equal(t, 25*time.Millisecond, r.StepTime())
+			require.Equal(t, 125*time.Millisecond, r.Duration())
+			require.Equal(t, "song.mp3", r.MediaFile())
+			require.Equal(t, "test", r.Producer())
+
+			// Out of order to exercise the block cache.
+			for _, n := range []int{4, 0, 2, 3, 1} {
+				frame, err := r.Frame(n)
+				require.NoError(t, err)
+				require.Equal(t, testFrame(n), frame, n)
+			}
+
+			_, err = r.Frame(testFrames)
+			require.ErrorIs(t, err, fseq.ErrFrameOutOfRange)
+		})
+	}
+}
+
+func TestReaderSparse(t *testing.T) {
+	r, err := fseq.NewReader(bytes.NewReader(buildV2(t, fseq.CompressionNone, nil, [][2]uint32{{10, 4}, {100, 2}})))
+	require.NoError(t, err)
+
+	start, count := r.ChannelRange()
+	require.Equal(t, uint32(10), start)
+	require.Equal(t, uint32(92), count)
+
+	frame, err := r.Frame(1)
+	require.NoError(t, err)
+
+	full := make([]byte, 102)
+	r.ExpandFrame(frame, full)
+	require.Equal(t, frame[:4], full[10:14])
+	require.Equal(t, frame[4:], full[100:102])
+}
+
+func TestReaderV1(t *testing.T) {
+	vars := varHeader("mf", "song.mp3")
+
+	header := make([]byte, 28)
+	copy(header, "PSEQ")
+	binary.LittleEndian.PutUint16(header[4:], uint16(28+len(vars)))
+	header[6], header[7] = 0, 1
+	binary.LittleEndian.PutUint16(header[8:], 28)
+	binary.LittleEndian.PutUint32(header[10:], testChannels)
+	binary.LittleEndian.PutUint32(header[14:], testFrames)
+	header[18] = 50
+	header[24] = 1
+
+	b := append(header, vars...)
+	for n := 0; n < testFrames; n++ {
+		b = append(b, testFrame(n)...)
+	}
+
+	r, err := fseq.NewReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	require.Equal(t, uint8(1), r.MajorVersion)
+	require.Equal(t, uint8(1), r.Gamma)
+	require.Equal(t, "song.mp3", r.MediaFile())
+
+	frame, err := r.Frame(3)
+	require.NoError(t, err)
+	require.Equal(t, testFrame(3), frame)
+}
+
+func TestReaderBadMagic(t *testing.T) {
+	_, err := fseq.NewReader(bytes.NewReader(make([]byte, 64)))
+	require.ErrorIs(t, err, fseq.ErrBadMagic)
+}
+
+func TestReaderBadHeader(t *testing.T) {
+	header := func(size int, channelDataOffset uint16) []byte {
+		b := make([]byte, size)
+		copy(b, "PSEQ")
+		binary.LittleEndian.PutUint16(b[4:], channelDataOffset)
+		b[7] = 2
+
+		return b
+	}
+
+	tests := map[string][]byte{
+		"offset inside header": header(64, 16),
+		"truncated":            header(40, 64),
+		"short":                header(20, 64),
+	}
+
+	for name, data := range tests {
+		data := data
+
+		t.Run(name, func(t *testing.T) {
+			_, err := fseq.NewReader(bytes.NewReader(data))
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package fseq
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	defaultStepTime   = 50 * time.Millisecond
+	defaultBlockBytes = 1 << 20
+	defaultMaxBlocks  = 255
+)
+
+var ErrWriterClosed = errors.New("fseq: writer closed")
+
+type WriterOptions struct {
+	// ChannelCount is the number of channels in each frame written, it
+	// defaults to the total of the sparse ranges.
+	ChannelCount uint32
+
+	// StepTime is the frame interval in whole milliseconds, defaults to 50ms.
+	StepTime time.Duration
+
+	Compression Compression
+
+	// FramesPerBlock is the number of frames in each compressed block,
+	// defaults to roughly 1MiB of channel data.
+	FramesPerBlock int
+
+	// MaxBlocks is the number of block table entries reserved in the header,
+	// once they're used up the final block takes every remaining frame.
+	// Defaults to and may not exceed 255.
+	MaxBlocks int
+
+	SparseRanges    []SparseRange
+	VariableHeaders []VariableHeader
+
+	// UniqueID defaults to the current time in microseconds, as xLights does.
+	UniqueID uint64
+}
+
+// NewVariableHeader creates a null terminated string header, such as
+// NewVariableHeader("mf", "song.mp3").
+func NewVariableHeader(code, value string) VariableHeader {
+	return VariableHeader{Code: code, Data: append([]byte(value), 0)}
+}
+
+// Writer streams frames into a version 2 FSEQ file, the header is
+// rewritten with the final frame count and block table on Close.
+type Writer struct {
+	Header
+
+	w       io.WriteSeeker
+	opts    WriterOptions
+	block   []byte
+	inBlock int
+	zstd    *zstd.Encoder
+	closed  bool
+}
+
+func NewWriter(w io.WriteSeeker, opts WriterOptions) (*Writer, error) {
+	if opts.ChannelCount == 0 {
+		for _, sr := range opts.SparseRanges {
+			opts.ChannelCount += sr.ChannelCount
+		}
+	}
+
+	if opts.ChannelCount == 0 {
+		return nil, errors.New("fseq: no channels")
+	}
+
+	if opts.StepTime == 0 {
+		opts.StepTime = defaultStepTime
+	}
+
+	stepMS := opts.StepTime / time.Millisecond
+	if stepMS < 1 || stepMS > 255 {
+		return nil, fmt.Errorf("fseq: step time %s out of range", opts.StepTime)
+	}
+
+	if len(opts.SparseRanges) > 255 {
+		return nil, fmt.Errorf("fseq: too many sparse ranges (%d)", len(opts.SparseRanges))
+	}
+
+	if opts.FramesPerBlock <= 0 {
+		if opts.FramesPerBlock = defaultBlockBytes / int(opts.ChannelCount); opts.FramesPerBlock < 1 {
+			opts.FramesPerBlock = 1
+		}
+	}
+
+	if opts.MaxBlocks <= 0 || opts.MaxBlocks > defaultMaxBlocks {
+		opts.MaxBlocks = defaultMaxBlocks
+	}
+
+	if opts.UniqueID == 0 {
+		opts.UniqueID = uint64(time.Now().UnixMicro())
+	}
+
+	fw := Writer{
+		Header: Header{
+			MajorVersion:    2,
+			ChannelCount:    opts.ChannelCount,
+			StepTimeMS:      uint8(stepMS),
+			Compression:     opts.Compression,
+			UniqueID:        opts.UniqueID,
+			SparseRanges:    opts.SparseRanges,
+			VariableHeaders: opts.VariableHeaders,
+		},
+		w:    w,
+		opts: opts,
+	}
+
+	switch opts.Compression {
+	case CompressionNone, CompressionZlib:
+	case CompressionZstd:
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, fmt.Errorf("fseq: %w", err)
+		}
+
+		fw.zstd = enc
+	default:
+		return nil, fmt.Errorf("fseq: unsupported compression %s", opts.Compression)
+	}
+
+	header, err := fw.marshalHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	fw.ChannelDataOffset = uint16(len(header))
+
+	// Reserve the space, the real header is written on close.
+	if _, err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("fseq: unable to write header: %w", err)
+	}
+
+	return &fw, nil
+}
+
+func (w *Writer) reservedBlocks() int {
+	if w.Compression == CompressionNone {
+		return 0
+	}
+
+	return w.opts.MaxBlocks
+}
+
+func (w *Writer) marshalHeader() ([]byte, error) {
+	var vars []byte
+	for _, v := range w.VariableHeaders {
+		if len(v.Code) != 2 {
+			return nil, fmt.Errorf("fseq: variable header code %q must be two characters", v.Code)
+		}
+
+		length := 4 + len(v.Data)
+		if length > 0xffff {
+			return nil, fmt.Errorf("fseq: variable header %q too long", v.Code)
+		}
+
+		b := make([]byte, 4, length)
+		binary.LittleEndian.PutUint16(b, uint16(length))
+		copy(b[2:], v.Code)
+		vars = append(vars, append(b, v.Data...)...)
+	}
+
+	blocks := w.reservedBlocks()
+	varOffset := fixedHeaderLen + blocks*blockLen + len(w.SparseRanges)*sparseRangeLen
+
+	// Keep the channel data aligned, readers skip the zero padding.
+	dataOffset := (varOffset + len(vars) + 3) &^ 3
+	if dataOffset > 0xffff {
+		return nil, errors.New("fseq: header too large")
+	}
+
+	b := make([]byte, dataOffset)
+	copy(b, magic[:])
+	binary.LittleEndian.PutUint16(b[4:], uint16(dataOffset))
+	b[6] = w.MinorVersion
+	b[7] = w.MajorVersion
+	binary.LittleEndian.PutUint16(b[8:], uint16(varOffset))
+	binary.LittleEndian.PutUint32(b[10:], w.ChannelCount)
+	binary.LittleEndian.PutUint32(b[14:], w.FrameCount)
+	b[18] = w.StepTimeMS
+	b[20] = byte(w.Compression)
+	b[21] = byte(blocks)
+	b[22] = byte(len(w.SparseRanges))
+	binary.LittleEndian.PutUint64(b[24:], w.UniqueID)
+
+	for i, block := range w.Blocks {
+		binary.LittleEndian.PutUint32(b[fixedHeaderLen+i*blockLen:], block.FirstFrame)
+		binary.LittleEndian.PutUint32(b[fixedHeaderLen+i*blockLen+4:], block.Length)
+	}
+
+	for i, sr := range w.SparseRanges {
+		off := fixedHeaderLen + blocks*blockLen + i*sparseRangeLen
+		putUint24(b[off:], sr.StartChannel)
+		putUint24(b[off+3:], sr.ChannelCount)
+	}
+
+	copy(b[varOffset:], vars)
+
+	return b, nil
+}
+
+// WriteFrame appends a frame, which must be exactly ChannelCount bytes.
+func (w *Writer) WriteFrame(frame []byte) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+
+	if len(frame) != int(w.ChannelCount) {
+		return fmt.Errorf("fseq: frame has %d channels, expected %d", len(frame), w.ChannelCount)
+	}
+
+	if w.Compression == CompressionNone {
+		if _, err := w.w.Write(frame); err != nil {
+			return fmt.Errorf("fseq: unable to write frame %d: %w", w.FrameCount, err)
+		}
+
+		w.FrameCount++
+		return nil
+	}
+
+	w.block = append(w.block, frame...)
+	w.inBlock++
+	w.FrameCount++
+
+	// The last reserved block gets everything that's left.
+	if w.inBlock >= w.opts.FramesPerBlock && len(w.Blocks) < w.opts.MaxBlocks-1 {
+		return w.flushBlock()
+	}
+
+	return nil
+}
+
+func (w *Writer) flushBlock() error {
+	if w.inBlock == 0 {
+		return nil
+	}
+
+	var compressed []byte
+
+	if w.Compression == CompressionZstd {
+		compressed = w.zstd.EncodeAll(w.block, nil)
+	} else {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		if _, err := zw.Write(w.block); err != nil {
+			return fmt.Errorf("fseq: unable to compress block: %w", err)
+		}
+
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("fseq: unable to compress block: %w", err)
+		}
+
+		compressed = buf.Bytes()
+	}
+
+	if _, err := w.w.Write(compressed); err != nil {
+		return fmt.Errorf("fseq: unable to write block: %w", err)
+	}
+
+	w.Blocks = append(w.Blocks, Block{
+		FirstFrame: w.FrameCount - uint32(w.inBlock),
+		Length:     uint32(len(compressed)),
+	})
+
+	w.block = w.block[:0]
+	w.inBlock = 0
+
+	return nil
+}
+
+// Close flushes any pending frames and rewrites the header, it doesn't
+// close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+
+	w.closed = true
+
+	if w.Compression != CompressionNone {
+		if err := w.flushBlock(); err != nil {
+			return err
+		}
+
+		if w.zstd != nil {
+			w.zstd.Close()
+		}
+	}
+
+	header, err := w.marshalHeader()
+	if err != nil {
+		return err
+	}
+
+	end, err := w.w.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("fseq: unable to rewrite header: %w", err)
+	}
+
+	if _, err := w.w.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("fseq: unable to rewrite header: %w", err)
+	}
+
+	if _, err := w.w.Write(header); err != nil {
+		return fmt.Errorf("fseq: unable to rewrite header: %w", err)
+	}
+
+	if _, err := w.w.Seek(end, io.SeekStart); err != nil {
+		return fmt.Errorf("fseq: unable to rewrite header: %w", err)
+	}
+
+	return nil
+}
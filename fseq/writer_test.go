@@ -0,0 +1,76 @@
+package fseq_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient/fseq"
+)
+
+func TestWriterRoundTrip(t *testing.T) {
+	checks := []struct {
+		Name string
+		Opts fseq.WriterOptions
+	}{
+		{"none", fseq.WriterOptions{ChannelCount: testChannels}},
+		{"zstd", fseq.WriterOptions{ChannelCount: testChannels, Compression: fseq.CompressionZstd, FramesPerBlock: 2}},
+		{"zlib", fseq.WriterOptions{ChannelCount: testChannels, Compression: fseq.CompressionZlib, FramesPerBlock: 2}},
+		{"overflow", fseq.WriterOptions{ChannelCount: testChannels, Compression: fseq.CompressionZstd, FramesPerBlock: 1, MaxBlocks: 2}},
+		{"sparse", fseq.WriterOptions{SparseRanges: []fseq.SparseRange{{StartChannel: 100, ChannelCount: 4}, {StartChannel: 200, ChannelCount: 2}}}},
+	}
+
+	for _, check := range checks {
+		t.Run(check.Name, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "test.fseq")
+			f, err := os.Create(name)
+			require.NoError(t, err)
+
+			check.Opts.StepTime = 25 * time.Millisecond
+			check.Opts.VariableHeaders = []fseq.VariableHeader{fseq.NewVariableHeader("mf", "song.mp3")}
+
+			w, err := fseq.NewWriter(f, check.Opts)
+			require.NoError(t, err)
+
+			for n := 0; n < testFrames; n++ {
+				require.NoError(t, w.WriteFrame(testFrame(n)))
+			}
+
+			require.NoError(t, w.Close())
+			require.ErrorIs(t, w.WriteFrame(testFrame(0)), fseq.ErrWriterClosed)
+			require.NoError(t, f.Close())
+
+			r, closer, err := fseq.Open(name)
+			require.NoError(t, err)
+			defer closer.Close()
+
+			require.Equal(t, uint32(testFrames), r.FrameCount)
+			require.Equal(t, uint32(testChannels), r.ChannelCount)
+			require.Equal(t, 25*time.Millisecond, r.StepTime())
+			require.Equal(t, check.Opts.Compression, r.Compression)
+			require.Equal(t, check.Opts.SparseRanges, r.SparseRanges)
+			require.Equal(t, "song.mp3", r.MediaFile())
+			require.Zero(t, r.ChannelDataOffset%4)
+
+			for n := 0; n < testFrames; n++ {
+				frame, err := r.Frame(n)
+				require.NoError(t, err)
+				require.Equal(t, testFrame(n), frame, n)
+			}
+		})
+	}
+}
+
+func TestWriterFrameSize(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "test.fseq"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	w, err := fseq.NewWriter(f, fseq.WriterOptions{ChannelCount: 3})
+	require.NoError(t, err)
+	require.Error(t, w.WriteFrame(bytes.Repeat([]byte{1}, 4)))
+}
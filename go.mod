@@ -3,15 +3,14 @@ module github.com/freman/fppclient
 go 1.19
 
 require (
-	github.com/davecgh/go-spew v1.1.1
+	github.com/klauspost/compress v1.16.7
 	github.com/stretchr/testify v1.8.0
 )
 
 require (
 	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/fatih/color v1.13.0 // indirect
-	github.com/josharian/intern v1.0.0 // indirect
-	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/manifoldco/promptui v0.9.0 // indirect
 	github.com/mattn/go-colorable v0.1.9 // indirect
 	github.com/mattn/go-isatty v0.0.14 // indirect
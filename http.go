@@ -4,15 +4,147 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
+// Sentinel errors that a BodyError may wrap, use errors.Is to test for them.
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound     = errors.New("not found")
+	ErrModelLocked  = errors.New("model locked")
+	ErrServer       = errors.New("server error")
+	ErrFailed       = errors.New("request failed")
+)
+
+// A BodyError is returned when FPP refuses a request, either with a non 200
+// HTTP status or with a non OK status field in an otherwise successful response.
 type BodyError struct {
-	body []byte
-	err  error
+	Method     string
+	Path       string
+	StatusCode int
+	Body       []byte
+
+	// Status and Message are parsed out of the body when FPP provides them.
+	Status  string
+	Message string
+
+	err error
+}
+
+func newBodyError(method, path string, statusCode int, body []byte) *BodyError {
+	e := BodyError{
+		Method:     method,
+		Path:       path,
+		StatusCode: statusCode,
+		Body:       body,
+	}
+
+	var parsed struct {
+		Status  string `json:"Status"`
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+
+	if json.Unmarshal(body, &parsed) == nil {
+		e.Status = parsed.Status
+		e.Message = parsed.Message
+		if e.Message == "" {
+			e.Message = parsed.Error
+		}
+	}
+
+	e.err = classifyError(statusCode, e.Message)
+
+	return &e
+}
+
+func classifyError(statusCode int, message string) error {
+	switch {
+	case statusCode == http.StatusBadRequest:
+		return ErrBadRequest
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusLocked, statusCode == http.StatusConflict:
+		return ErrModelLocked
+	case statusCode >= http.StatusInternalServerError:
+		return ErrServer
+	case statusCode != http.StatusOK:
+		return ErrFailed
+	}
+
+	// FPP reports most failures as a 200 with a message, which is all
+	// there is to go on.
+	switch message := strings.ToLower(message); {
+	case strings.Contains(message, "locked"):
+		return ErrModelLocked
+	case strings.Contains(message, "not found"):
+		return ErrNotFound
+	}
+
+	return ErrFailed
+}
+
+func (e *BodyError) Error() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s %s: ", e.Method, e.Path)
+
+	if e.StatusCode != http.StatusOK {
+		fmt.Fprintf(&sb, "unexpected HTTP status %d", e.StatusCode)
+	} else {
+		fmt.Fprintf(&sb, "unexpected status %q", e.Status)
+	}
+
+	if e.Message != "" {
+		fmt.Fprintf(&sb, ": %s", e.Message)
+	}
+
+	return sb.String()
+}
+
+func (e *BodyError) Unwrap() error {
+	return e.err
+}
+
+// check returns a BodyError if FPP reported anything other than OK.
+func (s Status) check(method, path string) error {
+	if strings.EqualFold(s.Status, "OK") {
+		return nil
+	}
+
+	e := BodyError{
+		Method:     method,
+		Path:       path,
+		StatusCode: http.StatusOK,
+		Status:     s.Status,
+		Message:    s.Message,
+	}
+	e.err = classifyError(http.StatusOK, s.Message)
+
+	return &e
+}
+
+// parseStatus extracts the status from a response body, ok is false if the
+// body wasn't JSON or didn't carry a status.
+func parseStatus(body []byte) (status Status, ok bool) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return status, false
+	}
+
+	if err := json.Unmarshal(trimmed, &status); err != nil {
+		return status, false
+	}
+
+	return status, status.Status != ""
 }
 
 func (c Client) formatURL(path string) string {
@@ -33,6 +165,37 @@ func (c Client) httpGet(ctx context.Context, path string, v interface{}) error {
 	return c.httpDo(req, v)
 }
 
+// httpGetStatus is for endpoints that may respond with text or a JSON status,
+// a non OK status is returned as an error.
+func (c Client) httpGetStatus(ctx context.Context, path string) error {
+	return c.httpDoStatus(ctx, http.MethodGet, path, nil)
+}
+
+// httpDoStatus is httpGetStatus for any method, in is sent as JSON unless nil.
+func (c Client) httpDoStatus(ctx context.Context, method, path string, in interface{}) error {
+	var req *http.Request
+	var err error
+
+	if in != nil {
+		if req, err = c.newJSONRequest(ctx, method, path, in); err != nil {
+			return err
+		}
+	} else if req, err = http.NewRequestWithContext(ctx, method, c.formatURL(path), nil); err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	body, _, err := c.httpDoRaw(req)
+	if err != nil {
+		return err
+	}
+
+	if status, ok := parseStatus(body); ok {
+		return status.check(method, path)
+	}
+
+	return nil
+}
+
 func (c Client) httpPost(ctx context.Context, path string, in, out interface{}) error {
 	return c.httpDoWithJSON(ctx, http.MethodPost, path, in, out)
 
@@ -43,35 +206,42 @@ func (c Client) httpPut(ctx context.Context, path string, in, out interface{}) e
 }
 
 func (c Client) httpDoWithJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	req, err := c.newJSONRequest(ctx, method, path, in)
+	if err != nil {
+		return err
+	}
+
+	return c.httpDo(req, out)
+}
+
+func (c Client) newJSONRequest(ctx context.Context, method, path string, in interface{}) (*http.Request, error) {
 	u := c.formatURL(path)
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(in); err != nil {
-		return fmt.Errorf("unable to marshal object: %w", err)
+		return nil, fmt.Errorf("unable to marshal object: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, u, &buf)
 	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
+		return nil, fmt.Errorf("unable to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	return c.httpDo(req, out)
+	return req, nil
 }
 
 func (c Client) httpDo(req *http.Request, v interface{}) error {
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.httpDoResponse(req)
 	if err != nil {
 		return err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		// Help the server out by reading and discarding the body
-		io.Copy(io.Discard, resp.Body) //nolint:errcheck // don't actually care, we're just trying to be nice.
-		return fmt.Errorf("unexpected HTTP status %q (%d)", resp.Status, resp.StatusCode)
+	if c.decodeHook != nil {
+		return c.decodeStrict(req, resp.Body, v)
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
@@ -80,3 +250,62 @@ func (c Client) httpDo(req *http.Request, v interface{}) error {
 
 	return nil
 }
+
+// decodeStrict decodes as normal then reports any schema drift to the hook.
+func (c Client) decodeStrict(req *http.Request, r io.Reader, v interface{}) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+
+	// Report before decoding, so a type change that breaks decoding is
+	// still reported.
+	warnings, err := CheckSchema(body, v)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range warnings {
+		w.Method, w.Path = req.Method, req.URL.Path
+		c.decodeHook(w)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("unable to parse response: %w", err)
+	}
+
+	return nil
+}
+
+// httpDoRaw is for the endpoints that don't reliably return JSON.
+func (c Client) httpDoRaw(req *http.Request) (body []byte, contentType string, err error) {
+	resp, err := c.httpDoResponse(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer resp.Body.Close()
+
+	if body, err = io.ReadAll(resp.Body); err != nil {
+		return nil, "", fmt.Errorf("unable to read response: %w", err)
+	}
+
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// httpDoResponse performs the request, the caller must close the body of
+// the returned response.
+func (c Client) httpDoResponse(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newBodyError(req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,81 @@
+package fppclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestBodyError(t *testing.T) {
+	checks := []struct {
+		Code     int
+		Body     string
+		Sentinel error
+		Message  string
+	}{{
+		http.StatusNotFound,
+		`{"Status":"ERROR","message":"Model not found"}`,
+		fppclient.ErrNotFound,
+		"Model not found",
+	}, {
+		http.StatusUnauthorized,
+		`nope`,
+		fppclient.ErrUnauthorized,
+		"",
+	}, {
+		http.StatusOK,
+		`{"Status":"ERROR","message":"Model is locked"}`,
+		fppclient.ErrModelLocked,
+		"Model is locked",
+	}, {
+		http.StatusForbidden,
+		`{"Status":"ERROR","message":"Account locked"}`,
+		fppclient.ErrUnauthorized,
+		"Account locked",
+	}, {
+		http.StatusLocked,
+		`{"Status":"ERROR","message":"Busy"}`,
+		fppclient.ErrModelLocked,
+		"Busy",
+	}, {
+		http.StatusInternalServerError,
+		`{"status":"ERROR","error":"boom"}`,
+		fppclient.ErrServer,
+		"boom",
+	}}
+
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 2, Height: 2})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	for _, check := range checks {
+		srv.Fail(fpptest.Failure{
+			Path:       "/api/overlays/model/Matrix/clear",
+			StatusCode: check.Code,
+			Body:       check.Body,
+			Times:      1,
+		})
+
+		err = c.ClearOverlaysModel(context.TODO(), "Matrix")
+		require.ErrorIs(t, err, check.Sentinel)
+
+		var bodyErr *fppclient.BodyError
+		require.True(t, errors.As(err, &bodyErr))
+		require.Equal(t, http.MethodGet, bodyErr.Method)
+		require.Equal(t, "/api/overlays/model/Matrix/clear", bodyErr.Path)
+		require.Equal(t, check.Code, bodyErr.StatusCode)
+		require.Equal(t, check.Message, bodyErr.Message)
+	}
+
+	require.NoError(t, c.ClearOverlaysModel(context.TODO(), "Matrix"))
+}
@@ -0,0 +1,121 @@
+package fppclient
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/freman/fppclient/fseq"
+)
+
+func (m Model) channelsPerNode() int {
+	if m.ChannelCountPerNode > 0 {
+		return m.ChannelCountPerNode
+	}
+
+	return 3
+}
+
+// NodeLayout returns the position of each node in the model, in wiring
+// order. Each string is made up of StrandsPerString strands running the
+// length of the model which zig-zag back and forth, StartCorner says where
+// the first node is.
+func (m Model) NodeLayout() []image.Point {
+	vertical := strings.HasPrefix(strings.ToLower(m.Orientation), "v")
+
+	strandLen, strands := m.Width, m.Height
+	if vertical {
+		strandLen, strands = m.Height, m.Width
+	}
+
+	sps := m.StrandsPerString
+	if sps < 1 {
+		sps = 1
+	}
+
+	corner := strings.ToUpper(m.StartCorner)
+	flipY := strings.HasPrefix(corner, "B")
+	flipX := strings.HasSuffix(corner, "R")
+
+	points := make([]image.Point, 0, strandLen*strands)
+
+	for strand := 0; strand < strands; strand++ {
+		for i := 0; i < strandLen; i++ {
+			pos := i
+			if (strand%sps)%2 == 1 {
+				pos = strandLen - 1 - i
+			}
+
+			p := image.Point{X: pos, Y: strand}
+			if vertical {
+				p = image.Point{X: strand, Y: pos}
+			}
+
+			if flipX {
+				p.X = m.Width - 1 - p.X
+			}
+
+			if flipY {
+				p.Y = m.Height - 1 - p.Y
+			}
+
+			points = append(points, p)
+		}
+	}
+
+	return points
+}
+
+// Image renders the model from channel data, channels[0] being channel 1.
+func (m Model) Image(channels []byte) (*image.RGBA, error) {
+	cpn := m.channelsPerNode()
+	start := m.StartChannel - 1
+	layout := m.NodeLayout()
+
+	if start < 0 || start+len(layout)*cpn > len(channels) {
+		return nil, fmt.Errorf("model %q channels %d-%d outside of the %d available", m.Name, m.StartChannel, m.StartChannel+len(layout)*cpn-1, len(channels))
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, m.Width, m.Height))
+
+	for node, p := range layout {
+		img.SetRGBA(p.X, p.Y, nodeColor(channels[start+node*cpn:start+(node+1)*cpn]))
+	}
+
+	return img, nil
+}
+
+func nodeColor(c []byte) color.RGBA {
+	switch len(c) {
+	case 1:
+		return color.RGBA{c[0], c[0], c[0], 0xff}
+	case 4:
+		return color.RGBA{
+			uint8(constrainToByte(int(c[0]) + int(c[3]))),
+			uint8(constrainToByte(int(c[1]) + int(c[3]))),
+			uint8(constrainToByte(int(c[2]) + int(c[3]))),
+			0xff,
+		}
+	}
+
+	return color.RGBA{c[0], c[1], c[2], 0xff}
+}
+
+// FSEQFrame renders the model from frame n of the sequence.
+func (m Model) FSEQFrame(r *fseq.Reader, n int) (*image.RGBA, error) {
+	frame, err := r.Frame(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.SparseRanges) == 0 {
+		return m.Image(frame)
+	}
+
+	start, count := r.ChannelRange()
+	channels := make([]byte, start+count)
+	r.ExpandFrame(frame, channels)
+
+	return m.Image(channels)
+}
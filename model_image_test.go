@@ -0,0 +1,80 @@
+package fppclient_test
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fseq"
+)
+
+func TestModelNodeLayout(t *testing.T) {
+	checks := []struct {
+		Model fppclient.Model
+		Out   []image.Point
+	}{{
+		fppclient.Model{Width: 3, Height: 2, Orientation: "horizontal", StartCorner: "TL", StrandsPerString: 1},
+		[]image.Point{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {1, 1}, {2, 1}},
+	}, {
+		fppclient.Model{Width: 3, Height: 2, Orientation: "horizontal", StartCorner: "BL", StrandsPerString: 2},
+		[]image.Point{{0, 1}, {1, 1}, {2, 1}, {2, 0}, {1, 0}, {0, 0}},
+	}, {
+		fppclient.Model{Width: 2, Height: 3, Orientation: "vertical", StartCorner: "TR", StrandsPerString: 2},
+		[]image.Point{{1, 0}, {1, 1}, {1, 2}, {0, 2}, {0, 1}, {0, 0}},
+	}}
+
+	for _, check := range checks {
+		require.Equal(t, check.Out, check.Model.NodeLayout(), check.Model)
+	}
+}
+
+func TestModelFSEQFrame(t *testing.T) {
+	model := fppclient.Model{
+		Name:                "Matrix",
+		StartChannel:        4,
+		ChannelCount:        12,
+		ChannelCountPerNode: 3,
+		Width:               2,
+		Height:              2,
+		Orientation:         "horizontal",
+		StartCorner:         "TL",
+		StrandsPerString:    2,
+	}
+
+	name := filepath.Join(t.TempDir(), "test.fseq")
+	f, err := os.Create(name)
+	require.NoError(t, err)
+
+	w, err := fseq.NewWriter(f, fseq.WriterOptions{ChannelCount: 15})
+	require.NoError(t, err)
+	require.NoError(t, w.WriteFrame([]byte{
+		9, 9, 9,
+		255, 0, 0,
+		0, 255, 0,
+		0, 0, 255,
+		255, 255, 255,
+	}))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	r, closer, err := fseq.Open(name)
+	require.NoError(t, err)
+	defer closer.Close()
+
+	img, err := model.FSEQFrame(r, 0)
+	require.NoError(t, err)
+
+	require.Equal(t, color.RGBA{255, 0, 0, 255}, img.RGBAAt(0, 0))
+	require.Equal(t, color.RGBA{0, 255, 0, 255}, img.RGBAAt(1, 0))
+	require.Equal(t, color.RGBA{0, 0, 255, 255}, img.RGBAAt(1, 1))
+	require.Equal(t, color.RGBA{255, 255, 255, 255}, img.RGBAAt(0, 1))
+
+	model.StartChannel = 10
+	_, err = model.FSEQFrame(r, 0)
+	require.Error(t, err)
+}
@@ -3,7 +3,6 @@ package fppclient
 import (
 	"context"
 	"fmt"
-	"strings"
 )
 
 func constrainToByte(i int) int {
@@ -18,6 +17,14 @@ func constrainToByte(i int) int {
 	return i
 }
 
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
 func (c Client) GetOverlaysModels(ctx context.Context) (models Models, err error) {
 	if err = c.httpGet(ctx, "/api/overlays/models", &models); err != nil {
 		return nil, fmt.Errorf("unable to retrieve models: %w", err)
@@ -38,21 +45,33 @@ func (c Client) GetOverlaysModel(ctx context.Context, name string) (*Model, erro
 }
 
 func (c Client) ClearOverlaysModel(ctx context.Context, name string) error {
-	var resp Status
-
-	path := fmt.Sprintf("/api/overlays/model/%s/clear", name)
-	if err := c.httpGet(ctx, path, &resp); err != nil {
+	if err := c.overlays().ClearModel(ctx, name); err != nil {
 		return fmt.Errorf("unable to clear model %q: %w", name, err)
 	}
 
-	if !strings.EqualFold(resp.Status, "OK") {
-		return fmt.Errorf("unable to clear model %q: %s", name, resp.Message)
-	}
-
 	return nil
 }
 
-func (c Client) GetOverlaysModelData(ctx context.Context, name string, rle bool) (*ModelData, error) {
+type modelDataConfig struct {
+	decode bool
+}
+
+type ModelDataOption func(c *modelDataConfig)
+
+// WithDecodedData expands run length encoded data so ModelData.Data is
+// always plain RGB values.
+func WithDecodedData() ModelDataOption {
+	return func(c *modelDataConfig) {
+		c.decode = true
+	}
+}
+
+func (c Client) GetOverlaysModelData(ctx context.Context, name string, rle bool, opts ...ModelDataOption) (*ModelData, error) {
+	var cfg modelDataConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	var modelData ModelData
 
 	path := fmt.Sprintf("/api/overlays/model/%s/data", name)
@@ -64,69 +83,65 @@ func (c Client) GetOverlaysModelData(ctx context.Context, name string, rle bool)
 		return nil, fmt.Errorf("unable to retrieve model %q: %w", name, err)
 	}
 
+	if cfg.decode && modelData.RLE {
+		data, err := modelData.Pixels()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode model %q: %w", name, err)
+		}
+
+		modelData.Data, modelData.RLE = data, false
+	}
+
 	return &modelData, nil
 }
 
-func (c Client) SetOverlaysModelState(ctx context.Context, name string, state interface{}) error {
-	path := fmt.Sprintf("/api/overlays/model/%s/state", name)
+// checkOverlaysModelLock returns ErrModelLocked if the client was created
+// with WithOverlayLockCheck and the model is locked, as FPP would silently
+// ignore any writes to it.
+func (c Client) checkOverlaysModelLock(ctx context.Context, name string) error {
+	if !c.overlayLockCheck {
+		return nil
+	}
 
-	var resp Status
-	if err := c.httpPut(ctx, path, struct {
-		State interface{}
-	}{State: state}, &resp); err != nil {
-		return fmt.Errorf("unable to set model state: %q: %w", name, err)
+	data, err := c.GetOverlaysModelData(ctx, name, true)
+	if err != nil {
+		return err
 	}
 
-	if !strings.EqualFold(resp.Status, "OK") {
-		return fmt.Errorf("unable to set model state %q: %s", name, resp.Message)
+	return data.checkLocked(name)
+}
+
+func (c Client) SetOverlaysModelState(ctx context.Context, name string, state ModelState) error {
+	if err := c.overlays().SetModelState(ctx, name, state); err != nil {
+		return fmt.Errorf("unable to set model state %q: %w", name, err)
 	}
 
 	return nil
 }
 
 func (c Client) FillOverlaysModel(ctx context.Context, name string, r, g, b int) error {
-	path := fmt.Sprintf("/api/overlays/model/%s/fill", name)
-
-	fillReq := fillPixelRequest{
-		RGB: []int{
-			constrainToByte(r),
-			constrainToByte(g),
-			constrainToByte(b),
-		},
+	if err := c.checkOverlaysModelLock(ctx, name); err != nil {
+		return fmt.Errorf("unable to fill model %q: %w", name, err)
 	}
 
-	var resp Status
-	if err := c.httpPut(ctx, path, &fillReq, &resp); err != nil {
-		return fmt.Errorf("unable to fill model %q: %err", name, err)
-	}
+	r, g, b = c.ColorCorrectionFor(name).Apply(r, g, b)
 
-	if !strings.EqualFold(resp.Status, "OK") {
-		return fmt.Errorf("unable to fill model %q: %s", name, resp.Message)
+	if err := c.overlays().FillModel(ctx, name, r, g, b); err != nil {
+		return fmt.Errorf("unable to fill model %q: %w", name, err)
 	}
 
 	return nil
 }
 
 func (c Client) SetOverlaysModelPixel(ctx context.Context, name string, x, y, r, g, b int) error {
-	path := fmt.Sprintf("/api/overlays/model/%s/pixel", name)
-
-	pixelReq := fillPixelRequest{
-		X: x,
-		Y: y,
-		RGB: []int{
-			constrainToByte(r),
-			constrainToByte(g),
-			constrainToByte(b),
-		},
-	}
-
-	var resp Status
-	if err := c.httpPut(ctx, path, &pixelReq, &resp); err != nil {
+	if err := c.checkOverlaysModelLock(ctx, name); err != nil {
 		return fmt.Errorf("unable to set pixel on model %q: %w", name, err)
 	}
 
-	if !strings.EqualFold(resp.Status, "OK") {
-		return fmt.Errorf("unable to set pixel on model %q: %s", name, resp.Message)
+	r, g, b = c.ColorCorrectionFor(name).Apply(r, g, b)
+
+	if err := c.overlays().SetModelPixel(ctx, name, x, y, r, g, b); err != nil {
+		return fmt.Errorf("unable to set pixel on model %q: %w", name, err)
 	}
 
 	return nil
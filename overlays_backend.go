@@ -0,0 +1,113 @@
+package fppclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// OverlayBackend performs the writes to overlay models, by default through
+// FPP's HTTP API. Colours have already been corrected and constrained to
+// bytes by the time they reach the backend.
+type OverlayBackend interface {
+	SetModelState(ctx context.Context, name string, state ModelState) error
+	ClearModel(ctx context.Context, name string) error
+	FillModel(ctx context.Context, name string, r, g, b int) error
+	SetModelPixel(ctx context.Context, name string, x, y, r, g, b int) error
+
+	// SetModelData replaces the model's pixels with RGB values, row by row.
+	SetModelData(ctx context.Context, name string, data []int) error
+}
+
+// WithOverlayBackend replaces the backend used for overlay writes.
+func WithOverlayBackend(backend OverlayBackend) newArg {
+	return func(c *Client) {
+		c.overlayBackend = backend
+		c.shmDir = ""
+	}
+}
+
+func (c Client) overlays() OverlayBackend {
+	if c.overlayBackend != nil {
+		return c.overlayBackend
+	}
+
+	return httpOverlayBackend{c: c}
+}
+
+type httpOverlayBackend struct {
+	c Client
+}
+
+func (h httpOverlayBackend) SetModelState(ctx context.Context, name string, state ModelState) error {
+	path := fmt.Sprintf("/api/overlays/model/%s/state", name)
+
+	var resp Status
+	if err := h.c.httpPut(ctx, path, struct {
+		State ModelState
+	}{State: state}, &resp); err != nil {
+		return err
+	}
+
+	return resp.check(http.MethodPut, path)
+}
+
+func (h httpOverlayBackend) ClearModel(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/api/overlays/model/%s/clear", name)
+
+	var resp Status
+	if err := h.c.httpGet(ctx, path, &resp); err != nil {
+		return err
+	}
+
+	return resp.check(http.MethodGet, path)
+}
+
+func (h httpOverlayBackend) FillModel(ctx context.Context, name string, r, g, b int) error {
+	path := fmt.Sprintf("/api/overlays/model/%s/fill", name)
+
+	fillReq := fillPixelRequest{
+		RGB: []int{r, g, b},
+	}
+
+	var resp Status
+	if err := h.c.httpPut(ctx, path, &fillReq, &resp); err != nil {
+		return err
+	}
+
+	return resp.check(http.MethodPut, path)
+}
+
+func (h httpOverlayBackend) SetModelPixel(ctx context.Context, name string, x, y, r, g, b int) error {
+	path := fmt.Sprintf("/api/overlays/model/%s/pixel", name)
+
+	pixelReq := fillPixelRequest{
+		X:   x,
+		Y:   y,
+		RGB: []int{r, g, b},
+	}
+
+	var resp Status
+	if err := h.c.httpPut(ctx, path, &pixelReq, &resp); err != nil {
+		return err
+	}
+
+	return resp.check(http.MethodPut, path)
+}
+
+// SetModelData is sent run length encoded when that is smaller.
+func (h httpOverlayBackend) SetModelData(ctx context.Context, name string, data []int) error {
+	path := fmt.Sprintf("/api/overlays/model/%s/data", name)
+
+	rle, err := EncodeRLE(data)
+	if err != nil {
+		return err
+	}
+
+	req := modelDataRequest{Data: data}
+	if len(rle) < len(data) {
+		req = modelDataRequest{Data: rle, RLE: true}
+	}
+
+	return h.c.httpDoStatus(ctx, http.MethodPut, path, &req)
+}
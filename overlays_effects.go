@@ -0,0 +1,81 @@
+package fppclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// stopOverlaysEffect is the pseudo effect FPP uses to stop whatever is
+// running on a model.
+const stopOverlaysEffect = "Stop Effects"
+
+// OverlayEffect is one of FPP's built in pixel overlay effects, Args are
+// passed to StartOverlaysEffect in order.
+type OverlayEffect struct {
+	Name string       `json:"name"`
+	Args []CommandArg `json:"args"`
+}
+
+type OverlayEffects []OverlayEffect
+
+// Get returns the named effect.
+func (e OverlayEffects) Get(name string) (OverlayEffect, bool) {
+	for _, effect := range e {
+		if effect.Name == name {
+			return effect, true
+		}
+	}
+
+	return OverlayEffect{}, false
+}
+
+func (c Client) GetOverlaysEffects(ctx context.Context) (OverlayEffects, error) {
+	var names []string
+	if err := c.httpGet(ctx, "/api/overlays/effects", &names); err != nil {
+		return nil, fmt.Errorf("unable to retrieve effects: %w", err)
+	}
+
+	effects := make(OverlayEffects, 0, len(names))
+
+	for _, name := range names {
+		effect := OverlayEffect{Name: name}
+
+		path := fmt.Sprintf("/api/overlays/effects/%s", name)
+		if err := c.httpGet(ctx, path, &effect); err != nil {
+			return nil, fmt.Errorf("unable to retrieve effect %q: %w", name, err)
+		}
+
+		effects = append(effects, effect)
+	}
+
+	return effects, nil
+}
+
+// StartOverlaysEffect runs an effect on the model, autoEnable is the state
+// the model is put in while the effect runs. Args are sent as they are, so
+// colours in them aren't colour corrected.
+func (c Client) StartOverlaysEffect(ctx context.Context, name, effect string, autoEnable ModelState, args ...string) error {
+	if _, err := c.PostCommand(ctx, CommandOverlayModelEffect(name, autoEnable.String(), effect, args...)); err != nil {
+		return fmt.Errorf("unable to start effect %q on model %q: %w", effect, name, err)
+	}
+
+	return nil
+}
+
+func (c Client) StopOverlaysEffect(ctx context.Context, name string) error {
+	if _, err := c.PostCommand(ctx, CommandOverlayModelEffect(name, ModelStateDisabled.String(), stopOverlaysEffect)); err != nil {
+		return fmt.Errorf("unable to stop effects on model %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// IsOverlaysEffectRunning reports Model.EffectRunning for the named model.
+func (c Client) IsOverlaysEffectRunning(ctx context.Context, name string) (bool, error) {
+	model, err := c.GetOverlaysModel(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	return model.EffectRunning, nil
+}
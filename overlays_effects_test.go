@@ -0,0 +1,54 @@
+package fppclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestOverlaysEffects(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 4, Height: 2})
+
+	var fade fppclient.OverlayEffect
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"Color Fade","args":[{"name":"Color","type":"color","default":"#ff0000"},{"name":"Duration","type":"int","min":1,"max":60000}]}`), &fade))
+	srv.SetEffects(fade, fppclient.OverlayEffect{Name: "Stop Effects", Args: []fppclient.CommandArg{}})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+
+	effects, err := c.GetOverlaysEffects(ctx)
+	require.NoError(t, err)
+	require.Len(t, effects, 2)
+
+	got, ok := effects.Get("Color Fade")
+	require.True(t, ok)
+	require.Equal(t, "color", got.Args[0].Type)
+	require.Equal(t, fade, got)
+
+	require.NoError(t, c.StartOverlaysEffect(ctx, "Matrix", "Color Fade", fppclient.ModelStateEnabled, "#00ff00", "1000"))
+
+	running, err := c.IsOverlaysEffectRunning(ctx, "Matrix")
+	require.NoError(t, err)
+	require.True(t, running)
+
+	require.NoError(t, c.StopOverlaysEffect(ctx, "Matrix"))
+
+	running, err = c.IsOverlaysEffectRunning(ctx, "Matrix")
+	require.NoError(t, err)
+	require.False(t, running)
+
+	require.Equal(t, []fppclient.Command{
+		{Command: "Overlay Model Effect", Args: []string{"Matrix", "Enabled", "Color Fade", "#00ff00", "1000"}},
+		{Command: "Overlay Model Effect", Args: []string{"Matrix", "Disabled", "Stop Effects"}},
+	}, srv.Commands())
+}
@@ -0,0 +1,229 @@
+package fppclient
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"time"
+)
+
+// Fit controls how an image is scaled to the model.
+type Fit int
+
+const (
+	// FitContain scales the image to fit inside the model, letterboxing it.
+	FitContain Fit = iota
+	// FitCover scales the image to fill the model, cropping the excess.
+	FitCover
+	// FitStretch scales the image to the model ignoring its aspect ratio.
+	FitStretch
+	// FitCenter draws the image unscaled in the middle of the model.
+	FitCenter
+)
+
+const (
+	defaultGIFDelay = 100 * time.Millisecond
+	restoreTimeout  = 5 * time.Second
+)
+
+// fitRect returns where an image of size src should be drawn in dst.
+func fitRect(src image.Point, dst image.Rectangle, fit Fit) image.Rectangle {
+	if src.X == 0 || src.Y == 0 {
+		return image.Rectangle{}
+	}
+
+	w, h := dst.Dx(), dst.Dy()
+
+	switch fit {
+	case FitStretch:
+		return dst
+	case FitCenter:
+		w, h = src.X, src.Y
+	case FitCover:
+		if src.X*dst.Dy() < src.Y*dst.Dx() {
+			h = src.Y * w / src.X
+		} else {
+			w = src.X * h / src.Y
+		}
+	default:
+		if src.X*dst.Dy() > src.Y*dst.Dx() {
+			h = src.Y * w / src.X
+		} else {
+			w = src.X * h / src.Y
+		}
+	}
+
+	origin := dst.Min.Add(image.Point{X: (dst.Dx() - w) / 2, Y: (dst.Dy() - h) / 2})
+
+	return image.Rectangle{Min: origin, Max: origin.Add(image.Point{X: w, Y: h})}
+}
+
+// drawScaled draws src into r of dst using nearest neighbour scaling, which
+// suits the low resolution of most pixel models. Anything outside r is black.
+func drawScaled(dst draw.Image, r image.Rectangle, src image.Image) {
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+
+	sb := src.Bounds()
+	clip := r.Intersect(dst.Bounds())
+
+	for y := clip.Min.Y; y < clip.Max.Y; y++ {
+		sy := sb.Min.Y + (y-r.Min.Y)*sb.Dy()/r.Dy()
+		for x := clip.Min.X; x < clip.Max.X; x++ {
+			sx := sb.Min.X + (x-r.Min.X)*sb.Dx()/r.Dx()
+			dst.Set(x, y, src.At(sx, sy))
+		}
+	}
+}
+
+// playOnModel enables the model for the duration of fn then puts it back
+// the way it was.
+func (c Client) playOnModel(ctx context.Context, model Model, fn func(canvas *ModelCanvas) error) (err error) {
+	current, err := c.GetOverlaysModel(ctx, model.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := c.SetOverlaysModelState(ctx, model.Name, ModelStateEnabled); err != nil {
+		return err
+	}
+
+	defer func() {
+		// ctx may well be cancelled by now.
+		rctx, cancel := context.WithTimeout(context.Background(), restoreTimeout)
+		defer cancel()
+
+		if rerr := c.SetOverlaysModelState(rctx, model.Name, current.IsActive); rerr != nil && err == nil {
+			err = rerr
+		}
+	}()
+
+	canvas := c.NewModelCanvas(model)
+	canvas.MarkDirty()
+
+	return fn(canvas)
+}
+
+// PlayImage shows img on the model until ctx is done, then restores the
+// model's previous state.
+func (c Client) PlayImage(ctx context.Context, model Model, img image.Image, fit Fit) error {
+	return c.playOnModel(ctx, model, func(canvas *ModelCanvas) error {
+		drawScaled(canvas, fitRect(img.Bounds().Size(), canvas.Bounds(), fit), img)
+		if err := canvas.Flush(ctx); err != nil {
+			return err
+		}
+
+		<-ctx.Done()
+		return ctx.Err()
+	})
+}
+
+// GIFLoopForever is the GIFOptions.LoopCount that plays until ctx is done.
+const GIFLoopForever = -1
+
+type GIFOptions struct {
+	Fit Fit
+
+	// LoopCount is how many times to play the animation, zero uses the
+	// GIF's own loop count and GIFLoopForever plays it until ctx is done.
+	LoopCount int
+}
+
+// PlayGIF plays the animation on the model honouring its frame delays and
+// loop count, then restores the model's previous state. An animation that
+// loops forever plays until ctx is done.
+func (c Client) PlayGIF(ctx context.Context, model Model, g *gif.GIF, opts GIFOptions) error {
+	if len(g.Image) == 0 {
+		return fmt.Errorf("unable to play gif on model %q: no frames", model.Name)
+	}
+
+	frames := composeGIF(g)
+
+	// Zero plays forever, gif.GIF.LoopCount counts the repeats after the
+	// first play with -1 meaning none.
+	var plays int
+
+	switch {
+	case opts.LoopCount < 0:
+	case opts.LoopCount > 0:
+		plays = opts.LoopCount
+	case g.LoopCount < 0:
+		plays = 1
+	case g.LoopCount > 0:
+		plays = g.LoopCount + 1
+	}
+
+	return c.playOnModel(ctx, model, func(canvas *ModelCanvas) error {
+		r := fitRect(frames[0].Bounds().Size(), canvas.Bounds(), opts.Fit)
+
+		for played := 0; plays == 0 || played < plays; played++ {
+			for i, frame := range frames {
+				start := time.Now()
+
+				drawScaled(canvas, r, frame)
+				if err := canvas.Flush(ctx); err != nil {
+					return err
+				}
+
+				delay := defaultGIFDelay
+				if i < len(g.Delay) && g.Delay[i] > 1 {
+					delay = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(delay - time.Since(start)):
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// composeGIF renders each frame of the animation in full, applying the
+// disposal methods.
+func composeGIF(g *gif.GIF) []*image.RGBA {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		bounds = g.Image[0].Bounds()
+		for _, frame := range g.Image[1:] {
+			bounds = bounds.Union(frame.Bounds())
+		}
+	}
+
+	current := image.NewRGBA(bounds)
+	frames := make([]*image.RGBA, 0, len(g.Image))
+
+	for i, frame := range g.Image {
+		var previous *image.RGBA
+
+		disposal := byte(gif.DisposalNone)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			draw.Draw(previous, bounds, current, bounds.Min, draw.Src)
+		}
+
+		draw.Draw(current, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		out := image.NewRGBA(bounds)
+		draw.Draw(out, bounds, current, bounds.Min, draw.Src)
+		frames = append(frames, out)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(current, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			current = previous
+		}
+	}
+
+	return frames
+}
@@ -0,0 +1,117 @@
+package fppclient_test
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+// newPlayServer returns a fake with playModel on it, transparent as it was
+// left by whatever ran before.
+func newPlayServer() *fpptest.Server {
+	srv := fpptest.NewServer()
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 4, Height: 2, IsActive: fppclient.ModelStateTransparent})
+
+	return srv
+}
+
+// countRequests returns how many requests srv has seen for req.
+func countRequests(srv *fpptest.Server, req string) int {
+	var n int
+	for _, r := range srv.Requests() {
+		if r == req {
+			n++
+		}
+	}
+
+	return n
+}
+
+var playModel = fppclient.Model{Name: "Matrix", Width: 4, Height: 2}
+
+func TestPlayImage(t *testing.T) {
+	srv := newPlayServer()
+	defer srv.Close()
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	// A 1x1 red image letterboxed onto a 4x2 model fills the middle 2x2.
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, c.PlayImage(ctx, playModel, img, fppclient.FitContain), context.DeadlineExceeded)
+
+	// Enabled to play, then put back as it was.
+	require.Equal(t, 2, countRequests(srv, "PUT /api/overlays/model/Matrix/state"))
+	m, _ := srv.Model("Matrix")
+	require.Equal(t, fppclient.ModelStateTransparent, m.IsActive)
+
+	require.Equal(t, 1, countRequests(srv, "PUT /api/overlays/model/Matrix/data"))
+	data, _ := srv.ModelData("Matrix")
+	require.Equal(t, []int{
+		0, 0, 0, 255, 0, 0, 255, 0, 0, 0, 0, 0,
+		0, 0, 0, 255, 0, 0, 255, 0, 0, 0, 0, 0,
+	}, data)
+}
+
+func TestPlayGIF(t *testing.T) {
+	srv := newPlayServer()
+	defer srv.Close()
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	palette := color.Palette{color.Black, color.White}
+	frame := func(x int) *image.Paletted {
+		p := image.NewPaletted(image.Rect(0, 0, 4, 2), palette)
+		p.SetColorIndex(x, 0, 1)
+		return p
+	}
+
+	g := &gif.GIF{
+		Image:     []*image.Paletted{frame(0), frame(1)},
+		Delay:     []int{2, 2},
+		LoopCount: -1,
+	}
+
+	// The GIF's own loop count plays it once.
+	require.NoError(t, c.PlayGIF(context.TODO(), playModel, g, fppclient.GIFOptions{}))
+	require.Equal(t, 2, countRequests(srv, "PUT /api/overlays/model/Matrix/data"))
+
+	start := time.Now()
+	require.NoError(t, c.PlayGIF(context.TODO(), playModel, g, fppclient.GIFOptions{LoopCount: 2}))
+	require.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+
+	require.Equal(t, 4, countRequests(srv, "PUT /api/overlays/model/Matrix/state"))
+	require.Equal(t, 6, countRequests(srv, "PUT /api/overlays/model/Matrix/data"))
+
+	m, _ := srv.Model("Matrix")
+	require.Equal(t, fppclient.ModelStateTransparent, m.IsActive)
+
+	// It finishes on the last frame.
+	data, _ := srv.ModelData("Matrix")
+	require.Equal(t, []int{
+		0, 0, 0, 255, 255, 255, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}, data)
+
+	// Forced to loop forever, it only stops with ctx.
+	ctx, cancel := context.WithTimeout(context.TODO(), 150*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, c.PlayGIF(ctx, playModel, g, fppclient.GIFOptions{LoopCount: fppclient.GIFLoopForever}), context.DeadlineExceeded)
+
+	require.Greater(t, countRequests(srv, "PUT /api/overlays/model/Matrix/data"), 6+4)
+}
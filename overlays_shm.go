@@ -0,0 +1,192 @@
+package fppclient
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrSharedMemory is returned when a model's shared memory can't be used.
+var ErrSharedMemory = errors.New("shared memory unavailable")
+
+const (
+	// shmModelPrefix is prepended to the model name to find its buffer.
+	shmModelPrefix = "FPP-Model-Overlay-Buffer-"
+
+	// The buffer starts with a control block of width, height and flags,
+	// followed by the RGB data row by row.
+	shmHeaderLen = 12
+	shmDirtyFlag = 0x1
+)
+
+// ShmOverlayBackend writes model data straight into the pixel overlay
+// buffers fppd shares through /dev/shm, for code running on the FPP host.
+// Model state has no shared memory interface so it goes to Fallback, without
+// one it fails with ErrSharedMemory.
+type ShmOverlayBackend struct {
+	// Dir is where the buffers live, normally /dev/shm.
+	Dir      string
+	Fallback OverlayBackend
+
+	mu      sync.Mutex
+	regions map[string]*shmRegion
+}
+
+func NewShmOverlayBackend(dir string, fallback OverlayBackend) *ShmOverlayBackend {
+	return &ShmOverlayBackend{Dir: dir, Fallback: fallback}
+}
+
+// WithSharedMemoryOverlays writes overlay data through shared memory in dir,
+// falling back to HTTP for changes of model state.
+func WithSharedMemoryOverlays(dir string) newArg {
+	return func(c *Client) {
+		c.overlayBackend = nil
+		c.shmDir = dir
+	}
+}
+
+type shmRegion struct {
+	mem    []byte
+	width  int
+	height int
+}
+
+func (r *shmRegion) data() []byte {
+	return r.mem[shmHeaderLen : shmHeaderLen+r.width*r.height*3]
+}
+
+// markDirty tells fppd the buffer has changed.
+func (r *shmRegion) markDirty() {
+	flags := binary.LittleEndian.Uint32(r.mem[8:])
+	binary.LittleEndian.PutUint32(r.mem[8:], flags|shmDirtyFlag)
+}
+
+func shmFileName(name string) string {
+	return shmModelPrefix + strings.ReplaceAll(name, "/", "_")
+}
+
+func (s *ShmOverlayBackend) region(name string) (*shmRegion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.regions[name]; ok {
+		return r, nil
+	}
+
+	mem, err := mmapFile(filepath.Join(s.Dir, shmFileName(name)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSharedMemory, err)
+	}
+
+	if len(mem) < shmHeaderLen {
+		munmap(mem) //nolint:errcheck // already failing.
+		return nil, fmt.Errorf("%w: buffer for %q too small", ErrSharedMemory, name)
+	}
+
+	r := shmRegion{
+		mem:    mem,
+		width:  int(binary.LittleEndian.Uint32(mem)),
+		height: int(binary.LittleEndian.Uint32(mem[4:])),
+	}
+
+	if shmHeaderLen+r.width*r.height*3 > len(mem) {
+		munmap(mem) //nolint:errcheck // already failing.
+		return nil, fmt.Errorf("%w: buffer for %q smaller than %dx%d", ErrSharedMemory, name, r.width, r.height)
+	}
+
+	if s.regions == nil {
+		s.regions = map[string]*shmRegion{}
+	}
+
+	s.regions[name] = &r
+
+	return &r, nil
+}
+
+// Close unmaps every buffer that has been used.
+func (s *ShmOverlayBackend) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	for name, r := range s.regions {
+		if err := munmap(r.mem); err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		delete(s.regions, name)
+	}
+
+	return firstErr
+}
+
+func (s *ShmOverlayBackend) SetModelState(ctx context.Context, name string, state ModelState) error {
+	if s.Fallback == nil {
+		return fmt.Errorf("%w: model state can't be set through shared memory", ErrSharedMemory)
+	}
+
+	return s.Fallback.SetModelState(ctx, name, state)
+}
+
+func (s *ShmOverlayBackend) ClearModel(ctx context.Context, name string) error {
+	return s.FillModel(ctx, name, 0, 0, 0)
+}
+
+func (s *ShmOverlayBackend) FillModel(_ context.Context, name string, r, g, b int) error {
+	region, err := s.region(name)
+	if err != nil {
+		return err
+	}
+
+	data := region.data()
+	for i := 0; i < len(data); i += 3 {
+		data[i], data[i+1], data[i+2] = byte(r), byte(g), byte(b)
+	}
+
+	region.markDirty()
+
+	return nil
+}
+
+func (s *ShmOverlayBackend) SetModelPixel(_ context.Context, name string, x, y, r, g, b int) error {
+	region, err := s.region(name)
+	if err != nil {
+		return err
+	}
+
+	if x < 0 || y < 0 || x >= region.width || y >= region.height {
+		return fmt.Errorf("pixel %d,%d outside of %dx%d model", x, y, region.width, region.height)
+	}
+
+	data := region.data()
+	i := (y*region.width + x) * 3
+	data[i], data[i+1], data[i+2] = byte(r), byte(g), byte(b)
+
+	region.markDirty()
+
+	return nil
+}
+
+func (s *ShmOverlayBackend) SetModelData(_ context.Context, name string, data []int) error {
+	region, err := s.region(name)
+	if err != nil {
+		return err
+	}
+
+	buf := region.data()
+	if len(data) > len(buf) {
+		return fmt.Errorf("%d values is more than the %dx%d model holds", len(data), region.width, region.height)
+	}
+
+	for i, v := range data {
+		buf[i] = byte(constrainToByte(v))
+	}
+
+	region.markDirty()
+
+	return nil
+}
@@ -0,0 +1,100 @@
+//go:build linux
+
+package fppclient_test
+
+import (
+	"context"
+	"encoding/binary"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+// fakeShmBuffer lays out a buffer the way fppd does for a width x height model.
+func fakeShmBuffer(t *testing.T, dir, name string, width, height int) string {
+	b := make([]byte, 12+width*height*3)
+	binary.LittleEndian.PutUint32(b, uint32(width))
+	binary.LittleEndian.PutUint32(b[4:], uint32(height))
+
+	file := filepath.Join(dir, "FPP-Model-Overlay-Buffer-"+name)
+	require.NoError(t, os.WriteFile(file, b, 0o600))
+
+	return file
+}
+
+func TestShmOverlayBackend(t *testing.T) {
+	dir := t.TempDir()
+	file := fakeShmBuffer(t, dir, "Matrix", 2, 2)
+
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 2, Height: 2})
+
+	c, err := fppclient.New(srv.URL, fppclient.WithSharedMemoryOverlays(dir))
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+
+	require.NoError(t, c.SetOverlaysModelState(ctx, "Matrix", fppclient.ModelStateEnabled))
+	m, _ := srv.Model("Matrix")
+	require.Equal(t, fppclient.ModelStateEnabled, m.IsActive)
+
+	require.NoError(t, c.FillOverlaysModel(ctx, "Matrix", 1, 2, 3))
+	require.NoError(t, c.SetOverlaysModelPixel(ctx, "Matrix", 1, 1, 300, 0, 9))
+	require.Error(t, c.SetOverlaysModelPixel(ctx, "Matrix", 2, 0, 0, 0, 0))
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), binary.LittleEndian.Uint32(b[8:]))
+	require.Equal(t, []byte{1, 2, 3, 1, 2, 3, 1, 2, 3, 255, 0, 9}, b[12:])
+
+	// Clear the dirty flag as fppd would once it has read the buffer.
+	f, err := os.OpenFile(file, os.O_RDWR, 0)
+	require.NoError(t, err)
+	_, err = f.WriteAt(make([]byte, 4), 8)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	canvas := c.NewModelCanvas(fppclient.Model{Name: "Matrix", Width: 2, Height: 2})
+	canvas.Set(0, 1, color.RGBA{7, 8, 9, 255})
+	require.NoError(t, canvas.Flush(ctx))
+
+	b, err = os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), binary.LittleEndian.Uint32(b[8:]))
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 7, 8, 9, 0, 0, 0}, b[12:])
+
+	require.NoError(t, c.ClearOverlaysModel(ctx, "Matrix"))
+
+	b, err = os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 12), b[12:])
+
+	require.ErrorIs(t, c.FillOverlaysModel(ctx, "Missing", 0, 0, 0), fppclient.ErrSharedMemory)
+
+	// Only the state went over HTTP.
+	require.Equal(t, []string{"PUT /api/overlays/model/Matrix/state"}, srv.Requests())
+}
+
+func TestShmOverlayBackendLeftAlone(t *testing.T) {
+	dir := t.TempDir()
+	fakeShmBuffer(t, dir, "Matrix", 2, 2)
+
+	backend := &fppclient.ShmOverlayBackend{Dir: dir}
+	defer backend.Close()
+
+	c, err := fppclient.New("http://fpp.invalid", fppclient.WithOverlayBackend(backend))
+	require.NoError(t, err)
+
+	// Without a fallback there's nowhere to send the state.
+	require.Nil(t, backend.Fallback)
+	require.ErrorIs(t, c.SetOverlaysModelState(context.TODO(), "Matrix", fppclient.ModelStateEnabled), fppclient.ErrSharedMemory)
+	require.NoError(t, c.FillOverlaysModel(context.TODO(), "Matrix", 1, 2, 3))
+}
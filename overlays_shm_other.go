@@ -0,0 +1,13 @@
+//go:build !linux
+
+package fppclient
+
+import "errors"
+
+func mmapFile(string) ([]byte, error) {
+	return nil, errors.New("shared memory overlays are only supported on linux")
+}
+
+func munmap([]byte) error {
+	return nil
+}
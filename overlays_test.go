@@ -0,0 +1,62 @@
+package fppclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestSetOverlaysModelState(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 4, Height: 2})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, c.SetOverlaysModelState(context.TODO(), "Matrix", fppclient.ModelStateTransparentRGB))
+
+	m, ok := srv.Model("Matrix")
+	require.True(t, ok)
+	require.Equal(t, fppclient.ModelStateTransparentRGB, m.IsActive)
+
+	var model fppclient.Model
+	require.NoError(t, json.Unmarshal([]byte(`{"Name":"Matrix","isActive":2}`), &model))
+	require.Equal(t, fppclient.ModelStateTransparent, model.IsActive)
+	require.Equal(t, "Transparent", model.IsActive.String())
+}
+
+func TestOverlayLockCheck(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 1, Height: 1})
+	srv.LockModel("Matrix", true)
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, c.FillOverlaysModel(context.TODO(), "Matrix", 1, 2, 3))
+
+	c, err = fppclient.New(srv.URL, fppclient.WithOverlayLockCheck())
+	require.NoError(t, err)
+	require.ErrorIs(t, c.FillOverlaysModel(context.TODO(), "Matrix", 1, 2, 3), fppclient.ErrModelLocked)
+	require.ErrorIs(t, c.SetOverlaysModelPixel(context.TODO(), "Matrix", 0, 0, 1, 2, 3), fppclient.ErrModelLocked)
+	require.ErrorIs(t, c.SetOverlaysModelData(context.TODO(), "Matrix", []int{1, 2, 3}), fppclient.ErrModelLocked)
+
+	var writes int
+	for _, req := range srv.Requests() {
+		if req == "PUT /api/overlays/model/Matrix/fill" || req == "PUT /api/overlays/model/Matrix/pixel" || req == "PUT /api/overlays/model/Matrix/data" {
+			writes++
+		}
+	}
+	require.Equal(t, 1, writes)
+
+	data, _ := srv.ModelData("Matrix")
+	require.Equal(t, []int{0, 0, 0}, data)
+}
@@ -0,0 +1,135 @@
+package fppclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"net/http"
+)
+
+// ErrUnknownFont is returned when the requested font isn't installed on FPP.
+var ErrUnknownFont = errors.New("unknown font")
+
+type TextPosition string
+
+const (
+	TextCenter      TextPosition = "Center"
+	TextLeftToRight TextPosition = "L2R"
+	TextRightToLeft TextPosition = "R2L"
+	TextTopToBottom TextPosition = "T2B"
+	TextBottomToTop TextPosition = "B2T"
+)
+
+const (
+	defaultTextFont     = "FreeSans"
+	defaultTextSize     = 10
+	defaultTextSpeed    = 10
+	defaultTextPosition = TextCenter
+)
+
+type TextOptions struct {
+	Message string
+
+	// Font must be one of GetOverlaysFonts, defaults to FreeSans.
+	Font     string
+	FontSize int
+
+	AntiAlias bool
+	Color     color.Color
+
+	// Position is either TextCenter or the direction to scroll.
+	Position        TextPosition
+	PixelsPerSecond int
+
+	// AutoEnable turns the model on while the text is displayed.
+	AutoEnable bool
+}
+
+type textRequest struct {
+	Message         string `json:"Message"`
+	Color           string `json:"Color"`
+	Font            string `json:"Font"`
+	FontSize        int    `json:"FontSize"`
+	AntiAlias       bool   `json:"AntiAlias"`
+	Position        string `json:"Position"`
+	PixelsPerSecond int    `json:"PixelsPerSecond"`
+	AutoEnable      bool   `json:"AutoEnable"`
+}
+
+// DisplayOverlaysText renders text on the model using FPP's fonts, the
+// colour goes through the model's colour correction.
+func (c Client) DisplayOverlaysText(ctx context.Context, name string, opts TextOptions) error {
+	req, err := opts.request(c.ColorCorrectionFor(name))
+	if err != nil {
+		return fmt.Errorf("unable to display text on model %q: %w", name, err)
+	}
+
+	fonts, err := c.GetOverlaysFonts(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to display text on model %q: %w", name, err)
+	}
+
+	if !fonts.Contains(req.Font) {
+		return fmt.Errorf("unable to display text on model %q: %w %q", name, ErrUnknownFont, req.Font)
+	}
+
+	path := fmt.Sprintf("/api/overlays/model/%s/text", name)
+	if err := c.httpDoStatus(ctx, http.MethodPut, path, &req); err != nil {
+		return fmt.Errorf("unable to display text on model %q: %w", name, err)
+	}
+
+	return nil
+}
+
+func (o TextOptions) request(cc *ColorCorrection) (textRequest, error) {
+	req := textRequest{
+		Message:         o.Message,
+		Font:            o.Font,
+		FontSize:        o.FontSize,
+		AntiAlias:       o.AntiAlias,
+		Position:        string(o.Position),
+		PixelsPerSecond: o.PixelsPerSecond,
+		AutoEnable:      o.AutoEnable,
+	}
+
+	if req.Font == "" {
+		req.Font = defaultTextFont
+	}
+
+	if req.FontSize == 0 {
+		req.FontSize = defaultTextSize
+	}
+
+	if req.PixelsPerSecond == 0 {
+		req.PixelsPerSecond = defaultTextSpeed
+	}
+
+	if req.Position == "" {
+		req.Position = string(defaultTextPosition)
+	}
+
+	r, g, b := 255, 255, 255
+	if o.Color != nil {
+		rgba := color.RGBAModel.Convert(o.Color).(color.RGBA)
+		r, g, b = int(rgba.R), int(rgba.G), int(rgba.B)
+	}
+
+	req.Color = formatColor(cc.Apply(r, g, b))
+
+	switch TextPosition(req.Position) {
+	case TextCenter, TextLeftToRight, TextRightToLeft, TextTopToBottom, TextBottomToTop:
+	default:
+		return req, fmt.Errorf("invalid text position %q", req.Position)
+	}
+
+	if req.FontSize < 0 {
+		return req, fmt.Errorf("invalid font size %d", req.FontSize)
+	}
+
+	if req.PixelsPerSecond < 0 {
+		return req, fmt.Errorf("invalid speed %d pixels per second", req.PixelsPerSecond)
+	}
+
+	return req, nil
+}
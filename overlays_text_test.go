@@ -0,0 +1,49 @@
+package fppclient_test
+
+import (
+	"context"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestDisplayOverlaysText(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Sign", Width: 32, Height: 8})
+	srv.SetFonts("FreeSans", "Helvetica")
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, c.DisplayOverlaysText(context.TODO(), "Sign", fppclient.TextOptions{
+		Message:    "Show starts at 7",
+		Font:       "Helvetica",
+		Color:      color.RGBA{255, 128, 0, 255},
+		Position:   fppclient.TextRightToLeft,
+		AutoEnable: true,
+	}))
+
+	require.Equal(t, []fpptest.Text{{
+		Message:         "Show starts at 7",
+		Color:           "#ff8000",
+		Font:            "Helvetica",
+		FontSize:        10,
+		Position:        "R2L",
+		PixelsPerSecond: 10,
+		AutoEnable:      true,
+	}}, srv.Texts("Sign"))
+
+	err = c.DisplayOverlaysText(context.TODO(), "Sign", fppclient.TextOptions{Message: "x", Font: "Comic Sans"})
+	require.ErrorIs(t, err, fppclient.ErrUnknownFont)
+
+	err = c.DisplayOverlaysText(context.TODO(), "Sign", fppclient.TextOptions{Message: "x", Position: "Diagonal"})
+	require.Error(t, err)
+
+	require.Len(t, srv.Texts("Sign"), 1)
+}
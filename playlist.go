@@ -3,40 +3,136 @@ package fppclient
 import (
 	"context"
 	"fmt"
+	"net/http"
 )
 
 func (c Client) GetPlaylist(ctx context.Context, name string) (playlist Playlist, err error) {
 	path := fmt.Sprintf("/api/playlist/%s", name)
 
 	if err = c.httpGet(ctx, path, &playlist); err != nil {
-		return playlist, fmt.Errorf("unable to retrieve playlists: %w", err)
+		return playlist, fmt.Errorf("unable to retrieve playlist %q: %w", name, err)
 	}
 
 	return playlist, err
 }
 
+// StartPlaylistOptions controls how a playlist is started, StartItem is the
+// one based position of the main playlist entry to begin at, zero starts at
+// the beginning.
+type StartPlaylistOptions struct {
+	Repeat       bool
+	StartItem    int
+	IfNotRunning bool
+}
+
+func (c Client) StartPlaylist(ctx context.Context, name string, opts StartPlaylistOptions) error {
+	// The REST endpoint can't start part way through, fall back on the command for that.
+	if opts.StartItem > 0 {
+		if _, err := c.PostCommand(ctx, CommandStartPlaylistAtItem(name, opts.StartItem, opts.Repeat, opts.IfNotRunning)); err != nil {
+			return fmt.Errorf("unable to start playlist %q: %w", name, err)
+		}
+
+		return nil
+	}
+
+	if opts.IfNotRunning {
+		if _, err := c.PostCommand(ctx, CommandStartPlaylist(name, opts.Repeat, opts.IfNotRunning)); err != nil {
+			return fmt.Errorf("unable to start playlist %q: %w", name, err)
+		}
+
+		return nil
+	}
+
+	path := fmt.Sprintf("/api/playlist/%s/start/%d", name, boolToInt(opts.Repeat))
+	if err := c.httpGetStatus(ctx, path); err != nil {
+		return fmt.Errorf("unable to start playlist %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// CreatePlaylist adds a new playlist to FPP.
+func (c Client) CreatePlaylist(ctx context.Context, playlist Playlist) error {
+	if err := c.httpDoStatus(ctx, http.MethodPost, "/api/playlists", &playlist); err != nil {
+		return fmt.Errorf("unable to create playlist %q: %w", playlist.Name, err)
+	}
+
+	return nil
+}
+
+// UpdatePlaylist replaces the named playlist, creating it if needed.
+func (c Client) UpdatePlaylist(ctx context.Context, playlist Playlist) error {
+	path := fmt.Sprintf("/api/playlist/%s", playlist.Name)
+
+	if err := c.httpDoStatus(ctx, http.MethodPost, path, &playlist); err != nil {
+		return fmt.Errorf("unable to update playlist %q: %w", playlist.Name, err)
+	}
+
+	return nil
+}
+
+func (c Client) DeletePlaylist(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/api/playlist/%s", name)
+
+	if err := c.httpDoStatus(ctx, http.MethodDelete, path, nil); err != nil {
+		return fmt.Errorf("unable to delete playlist %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// RenamePlaylist saves the playlist under its new name then removes the old
+// one, FPP has no native rename so this isn't atomic.
+func (c Client) RenamePlaylist(ctx context.Context, oldName, newName string) error {
+	playlist, err := c.GetPlaylist(ctx, oldName)
+	if err != nil {
+		return fmt.Errorf("unable to rename playlist %q: %w", oldName, err)
+	}
+
+	playlist.Name = newName
+
+	if err := c.UpdatePlaylist(ctx, playlist); err != nil {
+		return fmt.Errorf("unable to rename playlist %q: %w", oldName, err)
+	}
+
+	if err := c.DeletePlaylist(ctx, oldName); err != nil {
+		return fmt.Errorf("unable to rename playlist %q: %w", oldName, err)
+	}
+
+	return nil
+}
+
 type Playlist struct {
-	Name         string            `json:"name"`
-	Version      int               `json:"version"`
-	Repeat       int               `json:"repeat"`
-	LoopCount    int               `json:"loopCount"`
-	Empty        bool              `json:"empty"`
-	Desc         string            `json:"desc"`
-	Random       int               `json:"random"`
-	LeadIn       []PlaylistEntries `json:"leadIn"`
-	MainPlaylist []PlaylistEntries `json:"mainPlaylist"`
-	LeadOut      []PlaylistEntries `json:"leadOut"`
-	PlaylistInfo PlaylistInfo      `json:"playlistInfo"`
-}
-
-type PlaylistEntries struct {
-	Type         string  `json:"type"`
-	Enabled      int     `json:"enabled"`
-	PlayOnce     int     `json:"playOnce"`
-	SequenceName string  `json:"sequenceName"`
-	MediaName    string  `json:"mediaName"`
-	VideoOut     string  `json:"videoOut"`
-	Duration     float64 `json:"duration"`
+	Name         string          `json:"name"`
+	Version      int             `json:"version"`
+	Repeat       int             `json:"repeat"`
+	LoopCount    int             `json:"loopCount"`
+	Empty        bool            `json:"empty"`
+	Desc         string          `json:"desc"`
+	Random       int             `json:"random"`
+	LeadIn       PlaylistEntries `json:"leadIn"`
+	MainPlaylist PlaylistEntries `json:"mainPlaylist"`
+	LeadOut      PlaylistEntries `json:"leadOut"`
+	PlaylistInfo PlaylistInfo    `json:"playlistInfo"`
+
+	// Extra holds fields not modelled above so updates don't lose them.
+	Extra Extra `json:"-"`
+}
+
+type playlistFields Playlist
+
+func (p *Playlist) UnmarshalJSON(b []byte) (err error) {
+	var tmp playlistFields
+	if tmp.Extra, err = unmarshalWithExtra(b, &tmp); err != nil {
+		return err
+	}
+
+	*p = Playlist(tmp)
+	return nil
+}
+
+func (p Playlist) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(playlistFields(p), p.Extra)
 }
 
 type PlaylistInfo struct {
@@ -0,0 +1,333 @@
+package fppclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// PlaylistEntry is implemented by every type of entry that can appear in a
+// playlist, fields that aren't modelled are kept in Base().Extra.
+type PlaylistEntry interface {
+	EntryType() string
+	Base() *EntryBase
+}
+
+// EntryBase holds the fields common to every playlist entry.
+type EntryBase struct {
+	Type     string  `json:"type"`
+	Enabled  int     `json:"enabled"`
+	PlayOnce int     `json:"playOnce"`
+	Note     string  `json:"note"`
+	Duration float64 `json:"duration"`
+
+	Extra Extra `json:"-"`
+
+	// raw is the entry as it was decoded, values that haven't changed are
+	// sent back exactly as FPP wrote them.
+	raw json.RawMessage
+}
+
+func (e *EntryBase) Base() *EntryBase {
+	return e
+}
+
+type SequenceEntry struct {
+	EntryBase
+	SequenceName string `json:"sequenceName"`
+}
+
+func (SequenceEntry) EntryType() string { return "sequence" }
+
+type MediaEntry struct {
+	EntryBase
+	MediaName string `json:"mediaName"`
+	VideoOut  string `json:"videoOut,omitempty"`
+}
+
+func (MediaEntry) EntryType() string { return "media" }
+
+// BothEntry plays a sequence synchronised with a media file.
+type BothEntry struct {
+	EntryBase
+	SequenceName string `json:"sequenceName"`
+	MediaName    string `json:"mediaName"`
+	VideoOut     string `json:"videoOut,omitempty"`
+}
+
+func (BothEntry) EntryType() string { return "both" }
+
+type PauseEntry struct {
+	EntryBase
+}
+
+func (PauseEntry) EntryType() string { return "pause" }
+
+type CommandEntry struct {
+	EntryBase
+	Command          string   `json:"command"`
+	Args             []string `json:"args"`
+	MultisyncCommand bool     `json:"multisyncCommand"`
+	MultisyncHosts   string   `json:"multisyncHosts,omitempty"`
+}
+
+func (CommandEntry) EntryType() string { return "command" }
+
+type BranchEntry struct {
+	EntryBase
+	BranchTest          string `json:"branchTest"`
+	StartTime           string `json:"startTime,omitempty"`
+	EndTime             string `json:"endTime,omitempty"`
+	IterationStart      Intish `json:"iterationStart"`
+	IterationCount      Intish `json:"iterationCount"`
+	TrueNextBranchType  string `json:"trueNextBranchType,omitempty"`
+	TrueNextSection     string `json:"trueNextSection,omitempty"`
+	TrueNextItem        Intish `json:"trueNextItem"`
+	TrueNextPlaylist    string `json:"trueNextPlaylist,omitempty"`
+	FalseNextBranchType string `json:"falseNextBranchType,omitempty"`
+	FalseNextSection    string `json:"falseNextSection,omitempty"`
+	FalseNextItem       Intish `json:"falseNextItem"`
+	FalseNextPlaylist   string `json:"falseNextPlaylist,omitempty"`
+}
+
+func (BranchEntry) EntryType() string { return "branch" }
+
+// DynamicEntry generates playlist entries at runtime from a file, URL,
+// script or plugin.
+type DynamicEntry struct {
+	EntryBase
+	SubType    string `json:"subType"`
+	Data       string `json:"data"`
+	PluginHost string `json:"pluginHost,omitempty"`
+	DrainQueue Intish `json:"drainQueue"`
+}
+
+func (DynamicEntry) EntryType() string { return "dynamic" }
+
+type ImageEntry struct {
+	EntryBase
+	ImagePath      string `json:"imagePath"`
+	TransitionType Intish `json:"transitionType"`
+	ModelName      string `json:"modelName,omitempty"`
+}
+
+func (ImageEntry) EntryType() string { return "image" }
+
+type PluginEntry struct {
+	EntryBase
+	Data string `json:"data"`
+}
+
+func (PluginEntry) EntryType() string { return "plugin" }
+
+type ScriptEntry struct {
+	EntryBase
+	ScriptName string `json:"scriptName"`
+	ScriptArgs string `json:"scriptArgs,omitempty"`
+	Blocking   Intish `json:"blocking"`
+}
+
+func (ScriptEntry) EntryType() string { return "script" }
+
+type URLEntry struct {
+	EntryBase
+	URL    string `json:"url"`
+	Method string `json:"method"`
+	Data   string `json:"data,omitempty"`
+}
+
+func (URLEntry) EntryType() string { return "url" }
+
+// RemapEntry copies a block of channels to another location.
+type RemapEntry struct {
+	EntryBase
+	Source      Intish `json:"source"`
+	Destination Intish `json:"destination"`
+	Count       Intish `json:"count"`
+	Loops       Intish `json:"loops"`
+	Reverse     Intish `json:"reverse"`
+}
+
+func (RemapEntry) EntryType() string { return "remap" }
+
+type VolumeEntry struct {
+	EntryBase
+	Volume Intish `json:"volume"`
+}
+
+func (VolumeEntry) EntryType() string { return "volume" }
+
+// PlaylistEntryPlaylist embeds another playlist.
+type PlaylistEntryPlaylist struct {
+	EntryBase
+	Name string `json:"name"`
+}
+
+func (PlaylistEntryPlaylist) EntryType() string { return "playlist" }
+
+// UnknownEntry is used for entry types this library doesn't know, all of
+// its fields end up in Extra.
+type UnknownEntry struct {
+	EntryBase
+}
+
+func (e UnknownEntry) EntryType() string { return e.Type }
+
+var playlistEntryTypes = map[string]func() PlaylistEntry{
+	"sequence": func() PlaylistEntry { return &SequenceEntry{} },
+	"media":    func() PlaylistEntry { return &MediaEntry{} },
+	"both":     func() PlaylistEntry { return &BothEntry{} },
+	"pause":    func() PlaylistEntry { return &PauseEntry{} },
+	"command":  func() PlaylistEntry { return &CommandEntry{} },
+	"branch":   func() PlaylistEntry { return &BranchEntry{} },
+	"dynamic":  func() PlaylistEntry { return &DynamicEntry{} },
+	"image":    func() PlaylistEntry { return &ImageEntry{} },
+	"plugin":   func() PlaylistEntry { return &PluginEntry{} },
+	"script":   func() PlaylistEntry { return &ScriptEntry{} },
+	"url":      func() PlaylistEntry { return &URLEntry{} },
+	"remap":    func() PlaylistEntry { return &RemapEntry{} },
+	"volume":   func() PlaylistEntry { return &VolumeEntry{} },
+	"playlist": func() PlaylistEntry { return &PlaylistEntryPlaylist{} },
+}
+
+// PlaylistEntries is a section of a playlist, decoding picks the concrete
+// entry type from the type field.
+type PlaylistEntries []PlaylistEntry
+
+func (p *PlaylistEntries) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	entries := make(PlaylistEntries, 0, len(raw))
+	for i, r := range raw {
+		entry, err := unmarshalPlaylistEntry(r)
+		if err != nil {
+			return fmt.Errorf("playlist entry %d: %w", i, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	*p = entries
+	return nil
+}
+
+func (p PlaylistEntries) MarshalJSON() ([]byte, error) {
+	raw := make([]json.RawMessage, 0, len(p))
+	for i, entry := range p {
+		b, err := marshalPlaylistEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("playlist entry %d: %w", i, err)
+		}
+
+		raw = append(raw, b)
+	}
+
+	return json.Marshal(raw)
+}
+
+func unmarshalPlaylistEntry(b []byte) (PlaylistEntry, error) {
+	var peek struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal(b, &peek); err != nil {
+		return nil, err
+	}
+
+	entry := PlaylistEntry(&UnknownEntry{})
+	if fn, ok := playlistEntryTypes[peek.Type]; ok {
+		entry = fn()
+	}
+
+	extra, err := unmarshalWithExtra(b, entry)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %q entry: %w", peek.Type, err)
+	}
+
+	entry.Base().Extra = extra
+	entry.Base().raw = b
+
+	return entry, nil
+}
+
+func marshalPlaylistEntry(entry PlaylistEntry) ([]byte, error) {
+	base := entry.Base()
+
+	b, err := marshalWithExtra(entry, base.Extra)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := parseRawObject(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if base.Type == "" {
+		obj.set("type", json.RawMessage(strconv.Quote(entry.EntryType())))
+	}
+
+	if base.raw != nil {
+		if obj, err = keepRawValues(base.raw, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj.MarshalJSON()
+}
+
+// keepRawValues orders obj like the entry it was decoded from and puts back
+// the original values of fields that haven't changed, so "2" stays "2" even
+// though it was decoded into an Intish.
+func keepRawValues(raw []byte, obj rawObject) (rawObject, error) {
+	orig, err := parseRawObject(raw)
+	if err != nil {
+		return obj, err
+	}
+
+	// What the original would be marshalled as, to tell which fields changed.
+	decoded, err := unmarshalPlaylistEntry(raw)
+	if err != nil {
+		return obj, err
+	}
+
+	b, err := marshalWithExtra(decoded, decoded.Base().Extra)
+	if err != nil {
+		return obj, err
+	}
+
+	canonical, err := parseRawObject(b)
+	if err != nil {
+		return obj, err
+	}
+
+	var out rawObject
+
+	for _, k := range orig.keys {
+		v, ok := obj.values[k]
+		was, wasOK := canonical.values[k]
+
+		switch {
+		case !ok && wasOK:
+			// Left out because it has been cleared.
+			continue
+		case !ok, bytes.Equal(v, was):
+			// Unchanged, or left out by omitempty both times.
+			v = orig.values[k]
+		}
+
+		out.set(k, v)
+	}
+
+	for _, k := range obj.keys {
+		if _, ok := out.values[k]; !ok {
+			out.set(k, obj.values[k])
+		}
+	}
+
+	return out, nil
+}
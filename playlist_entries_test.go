@@ -0,0 +1,88 @@
+package fppclient_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+)
+
+// testPlaylistEntries are entries as FPP writes them, one of each type.
+var testPlaylistEntries = []string{
+	`{"type":"sequence","enabled":1,"playOnce":0,"sequenceName":"Intro.fseq","duration":12.5,"note":""}`,
+	`{"type":"media","enabled":1,"playOnce":0,"mediaName":"Song.mp3","videoOut":"--Default--","duration":187.2,"note":"Encore"}`,
+	`{"type":"both","enabled":1,"playOnce":0,"sequenceName":"Song.fseq","mediaName":"Song.mp3","videoOut":"--Default--","timecode":"Default","duration":187.2,"note":""}`,
+	`{"type":"pause","enabled":1,"playOnce":0,"duration":5,"note":""}`,
+	`{"type":"command","enabled":1,"playOnce":0,"command":"Volume Set","args":["70"],"multisyncCommand":false,"multisyncHosts":"","duration":0,"note":""}`,
+	`{"type":"branch","enabled":1,"playOnce":0,"branchTest":"Loop","startTime":"00:00:00","endTime":"00:00:00","iterationStart":0,"iterationCount":"2","trueNextBranchType":"Index","trueNextSection":"","trueNextItem":0,"falseNextBranchType":"None","falseNextSection":"","falseNextItem":0,"duration":0,"note":""}`,
+	`{"type":"dynamic","enabled":1,"playOnce":0,"subType":"file","data":"/home/fpp/dynamic.json","drainQueue":"0","duration":0,"note":""}`,
+	`{"type":"image","enabled":1,"playOnce":0,"imagePath":"logo.png","transitionType":"0","modelName":"Matrix","duration":10,"note":""}`,
+	`{"type":"plugin","enabled":1,"playOnce":0,"data":"something","duration":0,"note":""}`,
+	`{"type":"script","enabled":1,"playOnce":0,"scriptName":"lights.sh","scriptArgs":"on","blocking":"1","duration":0,"note":""}`,
+	`{"type":"url","enabled":1,"playOnce":0,"url":"http://example.com/","method":"GET","data":"","duration":0,"note":""}`,
+	`{"type":"remap","enabled":1,"playOnce":0,"source":"1","destination":"513","count":"512","loops":"1","reverse":0,"duration":0,"note":""}`,
+	`{"type":"volume","enabled":1,"playOnce":0,"volume":"80","duration":0,"note":""}`,
+	`{"type":"playlist","enabled":1,"playOnce":0,"name":"Outro","duration":64,"note":""}`,
+	`{"type":"mqtt","enabled":1,"playOnce":0,"topic":"show/start","message":"go","duration":0,"note":""}`,
+}
+
+func TestPlaylistEntriesRoundTrip(t *testing.T) {
+	for _, entry := range testPlaylistEntries {
+		data := "[" + entry + "]"
+
+		var entries fppclient.PlaylistEntries
+		require.NoError(t, json.Unmarshal([]byte(data), &entries))
+		require.Len(t, entries, 1)
+		require.Equal(t, entries[0].Base().Type, entries[0].EntryType())
+
+		b, err := json.Marshal(entries)
+		require.NoError(t, err)
+		require.Equal(t, data, string(b))
+	}
+}
+
+func TestPlaylistEntriesDecode(t *testing.T) {
+	var entries fppclient.PlaylistEntries
+	require.NoError(t, json.Unmarshal([]byte("["+strings.Join(testPlaylistEntries, ",")+"]"), &entries))
+	require.Len(t, entries, len(testPlaylistEntries))
+
+	require.Equal(t, "Intro.fseq", entries[0].(*fppclient.SequenceEntry).SequenceName)
+	require.Equal(t, 5.0, entries[3].(*fppclient.PauseEntry).Duration)
+	require.Equal(t, []string{"70"}, entries[4].(*fppclient.CommandEntry).Args)
+	require.Equal(t, fppclient.Intish(2), entries[5].(*fppclient.BranchEntry).IterationCount)
+	require.Equal(t, fppclient.Intish(513), entries[11].(*fppclient.RemapEntry).Destination)
+	require.Equal(t, "Outro", entries[13].(*fppclient.PlaylistEntryPlaylist).Name)
+
+	unknown := entries[14].(*fppclient.UnknownEntry)
+	require.Equal(t, "mqtt", unknown.EntryType())
+	require.Contains(t, unknown.Extra, "topic")
+}
+
+func TestPlaylistEntriesMarshalChanges(t *testing.T) {
+	var entries fppclient.PlaylistEntries
+	require.NoError(t, json.Unmarshal([]byte("["+testPlaylistEntries[12]+"]"), &entries))
+
+	entries[0].(*fppclient.VolumeEntry).Volume = 60
+	entries[0].Base().Note = "Quieter"
+
+	b, err := json.Marshal(entries)
+	require.NoError(t, err)
+	require.Equal(t, `[{"type":"volume","enabled":1,"playOnce":0,"volume":60,"duration":0,"note":"Quieter"}]`, string(b))
+}
+
+func TestPlaylistEntryMarshalSetsType(t *testing.T) {
+	entry := &fppclient.VolumeEntry{
+		EntryBase: fppclient.EntryBase{Enabled: 1},
+		Volume:    50,
+	}
+
+	b, err := json.Marshal(fppclient.PlaylistEntries{entry})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"type": "volume", "enabled": 1, "playOnce": 0, "note": "", "duration": 0, "volume": 50}]`, string(b))
+
+	// The entry itself is left alone.
+	require.Empty(t, entry.Type)
+}
@@ -0,0 +1,75 @@
+package fppclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+const testPlaylistJSON = `{
+	"name": "Show",
+	"version": 3,
+	"repeat": 0,
+	"loopCount": 0,
+	"empty": false,
+	"desc": "Nightly show",
+	"random": 0,
+	"leadIn": [],
+	"mainPlaylist": [{
+		"type": "both",
+		"enabled": 1,
+		"playOnce": 0,
+		"sequenceName": "Intro.fseq",
+		"mediaName": "Intro.mp3",
+		"videoOut": "--Default--",
+		"duration": 42.5,
+		"note": "",
+		"timecode": "Default"
+	}],
+	"leadOut": [],
+	"playlistInfo": {"total_duration": 42.5, "total_items": 1},
+	"deleteTarget": false
+}`
+
+func TestPlaylistRoundTrip(t *testing.T) {
+	var playlist fppclient.Playlist
+	require.NoError(t, json.Unmarshal([]byte(testPlaylistJSON), &playlist))
+
+	require.Equal(t, "Show", playlist.Name)
+	require.Contains(t, playlist.Extra, "deleteTarget")
+
+	b, err := json.Marshal(playlist)
+	require.NoError(t, err)
+	require.JSONEq(t, testPlaylistJSON, string(b))
+}
+
+func TestPlaylistCRUD(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	var old fppclient.Playlist
+	require.NoError(t, json.Unmarshal([]byte(testPlaylistJSON), &old))
+	old.Name = "Old"
+	srv.AddPlaylist(old)
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, c.RenamePlaylist(context.TODO(), "Old", "New"))
+
+	_, ok := srv.Playlist("Old")
+	require.False(t, ok)
+
+	playlist, err := c.GetPlaylist(context.TODO(), "New")
+	require.NoError(t, err)
+	require.Equal(t, "New", playlist.Name)
+	require.Contains(t, playlist.Extra, "deleteTarget")
+
+	_, err = c.GetPlaylist(context.TODO(), "Old")
+	require.ErrorIs(t, err, fppclient.ErrNotFound)
+}
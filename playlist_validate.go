@@ -0,0 +1,202 @@
+package fppclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type IssueSeverity int
+
+const (
+	SeverityWarning IssueSeverity = iota
+	SeverityError
+)
+
+func (s IssueSeverity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+
+	return "warning"
+}
+
+// PlaylistIssue is a single problem found with a playlist entry.
+type PlaylistIssue struct {
+	Section  string
+	Index    int
+	Type     string
+	Severity IssueSeverity
+	Message  string
+}
+
+func (i PlaylistIssue) String() string {
+	return fmt.Sprintf("%s: %s[%d] (%s): %s", i.Severity, i.Section, i.Index, i.Type, i.Message)
+}
+
+// PlaylistReport is the result of ValidatePlaylist.
+type PlaylistReport struct {
+	Playlist string
+	Issues   []PlaylistIssue
+}
+
+// OK is true when there are no errors, warnings are allowed.
+func (r PlaylistReport) OK() bool {
+	return len(r.Errors()) == 0
+}
+
+func (r PlaylistReport) Errors() []PlaylistIssue {
+	var errs []PlaylistIssue
+	for _, i := range r.Issues {
+		if i.Severity == SeverityError {
+			errs = append(errs, i)
+		}
+	}
+
+	return errs
+}
+
+// playlistValidator caches the remote lookups while a playlist is validated.
+type playlistValidator struct {
+	c         Client
+	files     map[string]map[string]bool
+	playlists map[string]bool
+}
+
+func (v *playlistValidator) hasFile(ctx context.Context, dir, name string) (bool, error) {
+	if v.files[dir] == nil {
+		files, err := v.c.GetFiles(ctx, dir)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return false, err
+		}
+
+		// A directory FPP doesn't have is as good as an empty one.
+
+		v.files[dir] = make(map[string]bool, len(files))
+		for _, f := range files {
+			v.files[dir][f.Name] = true
+		}
+	}
+
+	return v.files[dir][name], nil
+}
+
+func (v *playlistValidator) hasPlaylist(ctx context.Context, name string) (bool, error) {
+	if v.playlists == nil {
+		playlists, err := v.c.GetPlaylists(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		v.playlists = make(map[string]bool, len(playlists))
+		for _, p := range playlists {
+			v.playlists[p] = true
+		}
+	}
+
+	return v.playlists[name], nil
+}
+
+// ValidatePlaylist checks every entry of the playlist against the files and
+// playlists present on the player, an error is only returned if FPP couldn't
+// be queried.
+func (c Client) ValidatePlaylist(ctx context.Context, playlist Playlist) (PlaylistReport, error) {
+	report := PlaylistReport{Playlist: playlist.Name}
+
+	v := playlistValidator{
+		c:     c,
+		files: map[string]map[string]bool{},
+	}
+
+	sections := []struct {
+		name    string
+		entries PlaylistEntries
+	}{
+		{"leadIn", playlist.LeadIn},
+		{"mainPlaylist", playlist.MainPlaylist},
+		{"leadOut", playlist.LeadOut},
+	}
+
+	for _, section := range sections {
+		for i, entry := range section.entries {
+			issues, err := v.validateEntry(ctx, entry)
+			if err != nil {
+				return report, fmt.Errorf("unable to validate playlist %q: %w", playlist.Name, err)
+			}
+
+			for _, issue := range issues {
+				issue.Section = section.name
+				issue.Index = i
+				issue.Type = entry.EntryType()
+				report.Issues = append(report.Issues, issue)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (v *playlistValidator) validateEntry(ctx context.Context, entry PlaylistEntry) (issues []PlaylistIssue, err error) {
+	warn := func(format string, args ...interface{}) {
+		issues = append(issues, PlaylistIssue{Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)})
+	}
+
+	fail := func(format string, args ...interface{}) {
+		issues = append(issues, PlaylistIssue{Severity: SeverityError, Message: fmt.Sprintf(format, args...)})
+	}
+
+	requireFile := func(what, name string, dirs ...string) error {
+		if name == "" {
+			fail("no %s specified", what)
+			return nil
+		}
+
+		for _, dir := range dirs {
+			found, err := v.hasFile(ctx, dir, name)
+			if err != nil || found {
+				return err
+			}
+		}
+
+		fail("%s %q not found", what, name)
+		return nil
+	}
+
+	base := entry.Base()
+	if base.Enabled == 0 {
+		warn("entry is disabled")
+	}
+
+	switch e := entry.(type) {
+	case *SequenceEntry:
+		err = requireFile("sequence", e.SequenceName, "sequences")
+	case *MediaEntry:
+		err = requireFile("media", e.MediaName, "music", "videos")
+	case *BothEntry:
+		if err = requireFile("sequence", e.SequenceName, "sequences"); err == nil {
+			err = requireFile("media", e.MediaName, "music", "videos")
+		}
+	case *ImageEntry:
+		err = requireFile("image", e.ImagePath, "images")
+	case *ScriptEntry:
+		err = requireFile("script", e.ScriptName, "scripts")
+	case *PlaylistEntryPlaylist:
+		var found bool
+		if found, err = v.hasPlaylist(ctx, e.Name); err == nil && !found {
+			fail("playlist %q not found", e.Name)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch entry.(type) {
+	case *SequenceEntry, *MediaEntry, *BothEntry, *PauseEntry:
+		if base.Duration <= 0 {
+			warn("entry has no duration")
+		}
+	}
+
+	return issues, nil
+}
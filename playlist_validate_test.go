@@ -0,0 +1,71 @@
+package fppclient_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestValidatePlaylist(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	for dir, names := range map[string][]string{
+		"sequences": {"Intro.fseq", "Song.fseq"},
+		"music":     {"Song.mp3"},
+		"scripts":   {"lights.sh"},
+	} {
+		for _, name := range names {
+			srv.AddFile(dir, name, nil, time.Now())
+		}
+	}
+
+	srv.AddPlaylist(fppclient.Playlist{Name: "Show"})
+	srv.AddPlaylist(fppclient.Playlist{Name: "Outro"})
+
+	// A player without a videos directory.
+	srv.Fail(fpptest.Failure{Path: "/api/files/videos", StatusCode: http.StatusNotFound})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	playlist := fppclient.Playlist{
+		Name: "Show",
+		LeadIn: fppclient.PlaylistEntries{
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Enabled: 1, Duration: 10}, SequenceName: "Intro.fseq"},
+		},
+		MainPlaylist: fppclient.PlaylistEntries{
+			&fppclient.BothEntry{EntryBase: fppclient.EntryBase{Enabled: 1, Duration: 180}, SequenceName: "Song.fseq", MediaName: "Song.mp3"},
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Enabled: 1, Duration: 10}, SequenceName: "Renamed.fseq"},
+			&fppclient.PauseEntry{EntryBase: fppclient.EntryBase{Enabled: 0}},
+			&fppclient.ScriptEntry{EntryBase: fppclient.EntryBase{Enabled: 1}, ScriptName: "lights.sh"},
+			&fppclient.MediaEntry{EntryBase: fppclient.EntryBase{Enabled: 1, Duration: 60}, MediaName: "Clip.mp4"},
+		},
+		LeadOut: fppclient.PlaylistEntries{
+			&fppclient.PlaylistEntryPlaylist{EntryBase: fppclient.EntryBase{Enabled: 1}, Name: "Missing"},
+		},
+	}
+
+	report, err := c.ValidatePlaylist(context.TODO(), playlist)
+	require.NoError(t, err)
+	require.False(t, report.OK())
+
+	var got []string
+	for _, issue := range report.Issues {
+		got = append(got, issue.String())
+	}
+
+	require.Equal(t, []string{
+		`error: mainPlaylist[1] (sequence): sequence "Renamed.fseq" not found`,
+		`warning: mainPlaylist[2] (pause): entry is disabled`,
+		`warning: mainPlaylist[2] (pause): entry has no duration`,
+		`error: mainPlaylist[4] (media): media "Clip.mp4" not found`,
+		`error: leadOut[0] (playlist): playlist "Missing" not found`,
+	}, got)
+}
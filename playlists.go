@@ -13,3 +13,62 @@ func (c Client) GetPlaylists(ctx context.Context) (playlists []string, err error
 
 	return playlists, err
 }
+
+// StopPlaylist stops the running playlist immediately.
+func (c Client) StopPlaylist(ctx context.Context) error {
+	if err := c.httpGetStatus(ctx, "/api/playlists/stop"); err != nil {
+		return fmt.Errorf("unable to stop playlist: %w", err)
+	}
+
+	return nil
+}
+
+// StopPlaylistGracefully stops the running playlist at the end of the current item.
+func (c Client) StopPlaylistGracefully(ctx context.Context) error {
+	if err := c.httpGetStatus(ctx, "/api/playlists/stopgracefully"); err != nil {
+		return fmt.Errorf("unable to stop playlist gracefully: %w", err)
+	}
+
+	return nil
+}
+
+// StopPlaylistGracefullyAfterLoop stops the running playlist at the end of the current loop.
+func (c Client) StopPlaylistGracefullyAfterLoop(ctx context.Context) error {
+	if err := c.httpGetStatus(ctx, "/api/playlists/stopgracefullyafterloop"); err != nil {
+		return fmt.Errorf("unable to stop playlist gracefully after loop: %w", err)
+	}
+
+	return nil
+}
+
+func (c Client) PausePlaylist(ctx context.Context) error {
+	if err := c.httpGetStatus(ctx, "/api/playlists/pause"); err != nil {
+		return fmt.Errorf("unable to pause playlist: %w", err)
+	}
+
+	return nil
+}
+
+func (c Client) ResumePlaylist(ctx context.Context) error {
+	if err := c.httpGetStatus(ctx, "/api/playlists/resume"); err != nil {
+		return fmt.Errorf("unable to resume playlist: %w", err)
+	}
+
+	return nil
+}
+
+func (c Client) NextPlaylistItem(ctx context.Context) error {
+	if _, err := c.PostCommand(ctx, CommandNextPlaylistItem()); err != nil {
+		return fmt.Errorf("unable to skip to next playlist item: %w", err)
+	}
+
+	return nil
+}
+
+func (c Client) PrevPlaylistItem(ctx context.Context) error {
+	if _, err := c.PostCommand(ctx, CommandPrevPlaylistItem()); err != nil {
+		return fmt.Errorf("unable to skip to previous playlist item: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package fppclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestPlaylistControl(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddPlaylist(fppclient.Playlist{
+		Name: "Show",
+		MainPlaylist: fppclient.PlaylistEntries{
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "one.fseq"},
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "two.fseq"},
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "three.fseq"},
+		},
+	})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+	require.NoError(t, c.StartPlaylist(ctx, "Show", fppclient.StartPlaylistOptions{Repeat: true}))
+	require.NoError(t, c.StartPlaylist(ctx, "Show", fppclient.StartPlaylistOptions{StartItem: 2}))
+	require.NoError(t, c.PausePlaylist(ctx))
+	require.Equal(t, "paused", srv.Status().StatusName)
+	require.NoError(t, c.ResumePlaylist(ctx))
+	require.NoError(t, c.NextPlaylistItem(ctx))
+	require.Equal(t, "three.fseq", srv.Status().CurrentSequence)
+	require.NoError(t, c.PrevPlaylistItem(ctx))
+	require.Equal(t, "two.fseq", srv.Status().CurrentSequence)
+	require.NoError(t, c.StopPlaylist(ctx))
+	require.Equal(t, "idle", srv.Status().StatusName)
+	require.NoError(t, c.StopPlaylistGracefully(ctx))
+	require.NoError(t, c.StopPlaylistGracefullyAfterLoop(ctx))
+
+	require.Equal(t, []string{
+		"GET /api/playlist/Show/start/1",
+		"POST /api/command",
+		"GET /api/playlists/pause",
+		"GET /api/playlists/resume",
+		"POST /api/command",
+		"POST /api/command",
+		"GET /api/playlists/stop",
+		"GET /api/playlists/stopgracefully",
+		"GET /api/playlists/stopgracefullyafterloop",
+	}, srv.Requests())
+
+	var commands []string
+	for _, cmd := range srv.Commands() {
+		commands = append(commands, cmd.Command)
+	}
+
+	require.Equal(t, []string{"Start Playlist At Item", "Next Playlist Item", "Prev Playlist Item"}, commands)
+}
+
+func TestStartPlaylistAtItem(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddPlaylist(fppclient.Playlist{
+		Name: "Show",
+		MainPlaylist: fppclient.PlaylistEntries{
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "one.fseq"},
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "two.fseq"},
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "three.fseq"},
+		},
+	})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx := context.TODO()
+
+	require.NoError(t, c.StartPlaylist(ctx, "Show", fppclient.StartPlaylistOptions{}))
+	require.Equal(t, "one.fseq", srv.Status().CurrentSequence)
+
+	require.NoError(t, c.StartPlaylist(ctx, "Show", fppclient.StartPlaylistOptions{StartItem: 1}))
+	require.Equal(t, "one.fseq", srv.Status().CurrentSequence)
+
+	require.NoError(t, c.StartPlaylist(ctx, "Show", fppclient.StartPlaylistOptions{StartItem: 3}))
+	require.Equal(t, "three.fseq", srv.Status().CurrentSequence)
+	require.Equal(t, 3, srv.Status().CurrentPlaylist.Index)
+
+	require.Equal(t, []string{"1", "3"}, []string{srv.Commands()[0].Args[1], srv.Commands()[1].Args[1]})
+}
@@ -0,0 +1,79 @@
+package fppclient
+
+import "fmt"
+
+// maxRLEValues caps what DecodeRLE will expand to, it's the most channels
+// FPP supports.
+const maxRLEValues = 8 * 1024 * 1024
+
+// EncodeRLE compresses RGB data into FPP's run length encoding, a sequence
+// of count, r, g, b. The length of data must be a multiple of 3.
+func EncodeRLE(data []int) ([]int, error) {
+	if len(data)%3 != 0 {
+		return nil, fmt.Errorf("invalid RGB data, length %d is not a multiple of 3", len(data))
+	}
+
+	var out []int
+
+	for i := 0; i+2 < len(data); {
+		r, g, b := data[i], data[i+1], data[i+2]
+		count := 1
+
+		for j := i + 3; j+2 < len(data) && data[j] == r && data[j+1] == g && data[j+2] == b; j += 3 {
+			count++
+		}
+
+		out = append(out, count, r, g, b)
+		i += count * 3
+	}
+
+	return out, nil
+}
+
+// DecodeRLE expands FPP's run length encoded data into RGB values, data
+// that would expand past the most channels FPP supports is refused.
+func DecodeRLE(rle []int) ([]int, error) {
+	if len(rle)%4 != 0 {
+		return nil, fmt.Errorf("invalid RLE data, length %d is not a multiple of 4", len(rle))
+	}
+
+	total := 0
+
+	for i := 0; i < len(rle); i += 4 {
+		count := rle[i]
+		if count < 0 {
+			return nil, fmt.Errorf("invalid RLE data, negative count at %d", i)
+		}
+
+		if count > maxRLEValues/3-total {
+			return nil, fmt.Errorf("invalid RLE data, expands past %d channels", maxRLEValues)
+		}
+
+		total += count
+	}
+
+	if total == 0 {
+		return nil, nil
+	}
+
+	out := make([]int, 0, total*3)
+
+	for i := 0; i < len(rle); i += 4 {
+		count := rle[i]
+
+		for n := 0; n < count; n++ {
+			out = append(out, rle[i+1], rle[i+2], rle[i+3])
+		}
+	}
+
+	return out, nil
+}
+
+// Pixels returns the data as RGB values whichever format it was sent in.
+func (m ModelData) Pixels() ([]int, error) {
+	if !m.RLE {
+		return m.Data, nil
+	}
+
+	return DecodeRLE(m.Data)
+}
@@ -0,0 +1,74 @@
+package fppclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+func TestRLE(t *testing.T) {
+	checks := []struct {
+		Data []int
+		RLE  []int
+	}{
+		{nil, nil},
+		{[]int{1, 2, 3}, []int{1, 1, 2, 3}},
+		{[]int{0, 0, 0, 0, 0, 0, 9, 9, 9}, []int{2, 0, 0, 0, 1, 9, 9, 9}},
+		{[]int{5, 5, 5, 5, 5, 5, 5, 5, 5}, []int{3, 5, 5, 5}},
+	}
+
+	for _, check := range checks {
+		encoded, err := fppclient.EncodeRLE(check.Data)
+		require.NoError(t, err)
+		require.Equal(t, check.RLE, encoded)
+
+		decoded, err := fppclient.DecodeRLE(check.RLE)
+		require.NoError(t, err)
+		require.Equal(t, check.Data, decoded)
+	}
+
+	_, err := fppclient.EncodeRLE([]int{1, 2, 3, 4})
+	require.Error(t, err)
+
+	_, err = fppclient.DecodeRLE([]int{1, 2, 3})
+	require.Error(t, err)
+
+	_, err = fppclient.DecodeRLE([]int{-1, 2, 3, 4})
+	require.Error(t, err)
+
+	// Far more than any player has channels.
+	_, err = fppclient.DecodeRLE([]int{1 << 40, 1, 2, 3})
+	require.Error(t, err)
+
+	_, err = fppclient.DecodeRLE([]int{1 << 21, 1, 2, 3, 1 << 21, 1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestGetOverlaysModelDataDecoded(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddModel(fppclient.Model{Name: "Matrix", Width: 2, Height: 1})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, c.FillOverlaysModel(context.TODO(), "Matrix", 1, 2, 3))
+
+	data, err := c.GetOverlaysModelData(context.TODO(), "Matrix", true)
+	require.NoError(t, err)
+	require.True(t, data.RLE)
+	require.Equal(t, []int{2, 1, 2, 3}, data.Data)
+
+	pixels, err := data.Pixels()
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 1, 2, 3}, pixels)
+
+	data, err = c.GetOverlaysModelData(context.TODO(), "Matrix", true, fppclient.WithDecodedData())
+	require.NoError(t, err)
+	require.False(t, data.RLE)
+	require.Equal(t, []int{1, 2, 3, 1, 2, 3}, data.Data)
+}
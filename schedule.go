@@ -2,8 +2,8 @@ package fppclient
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
 )
 
 func (c Client) GetSchedule(ctx context.Context) (schedule []ScheduleEntries, err error) {
@@ -26,11 +26,11 @@ func (c Client) PostSchedule(ctx context.Context, scheduleIn []ScheduleEntries)
 func (c Client) PostScheduleReload(ctx context.Context) (err error) {
 	var s Status
 	if err = c.httpPost(ctx, "/api/schedule/reload", nil, &s); err != nil {
-		return err
+		return fmt.Errorf("unable to reload schedule: %w", err)
 	}
 
-	if s.Status != "OK" {
-		return errors.New(s.Message)
+	if err = s.check(http.MethodPost, "/api/schedule/reload"); err != nil {
+		return fmt.Errorf("unable to reload schedule: %w", err)
 	}
 
 	return nil
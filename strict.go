@@ -0,0 +1,402 @@
+package fppclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DecodeWarningKind is the kind of difference between a response and the
+// structure it was decoded into.
+type DecodeWarningKind int
+
+const (
+	// WarningUnknownField is a field in the response the structure doesn't have.
+	WarningUnknownField DecodeWarningKind = iota + 1
+	// WarningTypeMismatch is a value that can't be decoded into its field.
+	WarningTypeMismatch
+)
+
+func (k DecodeWarningKind) String() string {
+	switch k {
+	case WarningUnknownField:
+		return "unknown field"
+	case WarningTypeMismatch:
+		return "type mismatch"
+	}
+
+	return fmt.Sprintf("DecodeWarningKind(%d)", int(k))
+}
+
+// A DecodeWarning describes where a response from FPP has drifted from the
+// structures in this package.
+type DecodeWarning struct {
+	// Method and Path are the request, when the warning came from one.
+	Method string
+	Path   string
+
+	Kind DecodeWarningKind
+	// Field is the location in the response, e.g. current_playlist.count
+	// or sensors[0].value.
+	Field string
+	// Want is the Go type of the field, empty for unknown fields.
+	Want string
+	// Value is the offending JSON, truncated if it's long.
+	Value string
+}
+
+func (w DecodeWarning) String() string {
+	var sb strings.Builder
+
+	if w.Path != "" {
+		fmt.Fprintf(&sb, "%s %s: ", w.Method, w.Path)
+	}
+
+	fmt.Fprintf(&sb, "%s: %s", w.Field, w.Kind)
+
+	if w.Want != "" {
+		fmt.Fprintf(&sb, ", want %s", w.Want)
+	}
+
+	return fmt.Sprintf("%s, got %s", sb.String(), w.Value)
+}
+
+// WithStrictDecoding calls hook for every unknown field and type mismatch
+// found in responses. Decoding carries on as normal, so this is for finding
+// out when FPP changes rather than for refusing responses.
+func WithStrictDecoding(hook func(DecodeWarning)) newArg {
+	return func(c *Client) {
+		c.decodeHook = hook
+	}
+}
+
+// CheckSchema compares the JSON in data against the structure of v, which
+// is what data would be decoded into, and returns any differences sorted by
+// field. Types with their own UnmarshalJSON are checked by running it, except
+// those that keep unknown fields in Extra, which are checked field by field.
+func CheckSchema(data []byte, v interface{}) ([]DecodeWarning, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to parse response: %w", err)
+	}
+
+	var s schemaChecker
+	s.check("", doc, reflect.TypeOf(v))
+
+	sort.SliceStable(s.warnings, func(i, j int) bool {
+		return s.warnings[i].Field < s.warnings[j].Field
+	})
+
+	return s.warnings, nil
+}
+
+var (
+	unmarshalerType      = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	extraType            = reflect.TypeOf(Extra{})
+	playlistEntriesType  = reflect.TypeOf(PlaylistEntries{})
+	unknownPlaylistEntry = reflect.TypeOf(UnknownEntry{})
+)
+
+type schemaChecker struct {
+	warnings []DecodeWarning
+}
+
+func (s *schemaChecker) warn(kind DecodeWarningKind, field string, t reflect.Type, value interface{}) {
+	w := DecodeWarning{
+		Kind:  kind,
+		Field: field,
+		Value: describeJSON(value),
+	}
+
+	if t != nil {
+		w.Want = t.String()
+	}
+
+	s.warnings = append(s.warnings, w)
+}
+
+func (s *schemaChecker) check(field string, value interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if value == nil {
+		return
+	}
+
+	// These decode without complaint, stashing what they don't know in
+	// Extra, so the fields have to be compared by hand.
+	if t == playlistEntriesType {
+		s.checkPlaylistEntries(field, value)
+		return
+	}
+
+	if t.Kind() == reflect.Struct && hasExtra(t) {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			s.warn(WarningTypeMismatch, field, t, value)
+			return
+		}
+
+		s.checkStruct(field, obj, t)
+		return
+	}
+
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		b, _ := json.Marshal(value) //nolint:errcheck // it came from json.
+		if err := json.Unmarshal(b, reflect.New(t).Interface()); err != nil {
+			s.warn(WarningTypeMismatch, field, t, value)
+		}
+
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return
+	case reflect.Struct:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			s.warn(WarningTypeMismatch, field, t, value)
+			return
+		}
+
+		s.checkStruct(field, obj, t)
+	case reflect.Map:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			s.warn(WarningTypeMismatch, field, t, value)
+			return
+		}
+
+		for k, v := range obj {
+			s.check(joinField(field, k), v, t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := value.([]interface{})
+		if !ok {
+			// A []byte is base64 in JSON.
+			if _, isString := value.(string); isString && t.Elem().Kind() == reflect.Uint8 {
+				return
+			}
+
+			s.warn(WarningTypeMismatch, field, t, value)
+			return
+		}
+
+		for n, v := range arr {
+			s.check(fmt.Sprintf("%s[%d]", field, n), v, t.Elem())
+		}
+	default:
+		if !scalarFits(value, t) {
+			s.warn(WarningTypeMismatch, field, t, value)
+		}
+	}
+}
+
+func (s *schemaChecker) checkStruct(field string, obj map[string]interface{}, t reflect.Type) {
+	fields := jsonFields(t)
+
+	for key, v := range obj {
+		f, ok := fields[key]
+		if !ok {
+			// encoding/json falls back on a case insensitive match.
+			for name, candidate := range fields {
+				if strings.EqualFold(name, key) {
+					f, ok = candidate, true
+					break
+				}
+			}
+		}
+
+		name := joinField(field, key)
+
+		if !ok {
+			s.warn(WarningUnknownField, name, nil, v)
+			continue
+		}
+
+		if f.quoted {
+			str, isString := v.(string)
+			if !isString {
+				s.warn(WarningTypeMismatch, name, f.typ, v)
+				continue
+			}
+
+			var inner interface{} = str
+			if f.typ.Kind() != reflect.String {
+				inner = json.Number(str)
+			}
+
+			if !scalarFits(inner, f.typ) {
+				s.warn(WarningTypeMismatch, name, f.typ, v)
+			}
+
+			continue
+		}
+
+		s.check(name, v, f.typ)
+	}
+}
+
+// checkPlaylistEntries checks each entry against the type its type field
+// decodes into.
+func (s *schemaChecker) checkPlaylistEntries(field string, value interface{}) {
+	arr, ok := value.([]interface{})
+	if !ok {
+		s.warn(WarningTypeMismatch, field, playlistEntriesType, value)
+		return
+	}
+
+	for n, v := range arr {
+		name := fmt.Sprintf("%s[%d]", field, n)
+
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			s.warn(WarningTypeMismatch, name, unknownPlaylistEntry, v)
+			continue
+		}
+
+		t := unknownPlaylistEntry
+		if typ, _ := obj["type"].(string); playlistEntryTypes[typ] != nil {
+			t = reflect.TypeOf(playlistEntryTypes[typ]()).Elem()
+		}
+
+		s.checkStruct(name, obj, t)
+	}
+}
+
+// hasExtra reports whether t, or a struct embedded in it, keeps unknown
+// fields in an Extra.
+func hasExtra(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		if sf.Type == extraType {
+			return true
+		}
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && hasExtra(sf.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type jsonField struct {
+	typ    reflect.Type
+	quoted bool
+}
+
+// jsonFields maps the JSON names of a struct's fields to their types, taking
+// embedded structs into account.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := map[string]jsonField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for k, v := range jsonFields(ft) {
+				if _, ok := fields[k]; !ok {
+					fields[k] = v
+				}
+			}
+
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		fields[name] = jsonField{
+			typ:    sf.Type,
+			quoted: strings.Contains(","+opts+",", ",string,"),
+		}
+	}
+
+	return fields
+}
+
+// scalarFits reports whether a JSON scalar can be decoded into t.
+func scalarFits(value interface{}, t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool:
+		_, ok := value.(bool)
+		return ok
+	case reflect.String:
+		_, ok := value.(string)
+		return ok
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+
+		_, err := strconv.ParseInt(string(n), 10, t.Bits())
+		return err == nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+
+		_, err := strconv.ParseUint(string(n), 10, t.Bits())
+		return err == nil
+	case reflect.Float32, reflect.Float64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+
+		_, err := strconv.ParseFloat(string(n), t.Bits())
+		return err == nil
+	}
+
+	return false
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
+
+// describeJSON renders a value for a warning, keeping it short.
+func describeJSON(value interface{}) string {
+	const limit = 40
+
+	b, _ := json.Marshal(value) //nolint:errcheck // it came from json.
+	if len(b) > limit {
+		return string(b[:limit]) + "..."
+	}
+
+	return string(b)
+}
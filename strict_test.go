@@ -0,0 +1,125 @@
+package fppclient_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+var updateFixtures = flag.Bool("update", false, "rewrite the expected warnings of the response fixtures")
+
+// fixtureTypes maps the directories in testdata/fixtures to the structure
+// their responses are decoded into.
+var fixtureTypes = map[string]func() interface{}{
+	"ChannelOutputsObj":  func() interface{} { return &fppclient.ChannelOutputsObj{} },
+	"CommandDefinitions": func() interface{} { return &fppclient.CommandDefinitions{} },
+	"Files":              func() interface{} { return &fppclient.Files{} },
+	"Fonts":              func() interface{} { return &fppclient.Fonts{} },
+	"FPPDStatus":         func() interface{} { return &fppclient.FPPDStatus{} },
+	"ModelData":          func() interface{} { return &fppclient.ModelData{} },
+	"Models":             func() interface{} { return &fppclient.Models{} },
+	"Playlist":           func() interface{} { return &fppclient.Playlist{} },
+	"ScheduleResponse":   func() interface{} { return &fppclient.ScheduleResponse{} },
+}
+
+// TestResponseFixtures runs every response in testdata/fixtures/<Type>/
+// through strict decoding, comparing the drift found with the .warnings file
+// alongside it. Drop a response body captured from a new FPP version in the
+// right directory and run with -update to get a report of what changed.
+func TestResponseFixtures(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "fixtures", "*", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		typeName := filepath.Base(filepath.Dir(file))
+
+		t.Run(typeName+"/"+filepath.Base(file), func(t *testing.T) {
+			newValue, ok := fixtureTypes[typeName]
+			require.True(t, ok, "no structure registered for %s", typeName)
+
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			warnings, err := fppclient.CheckSchema(data, newValue())
+			require.NoError(t, err)
+
+			var report strings.Builder
+			for _, w := range warnings {
+				report.WriteString(w.String() + "\n")
+			}
+
+			golden := strings.TrimSuffix(file, ".json") + ".warnings"
+
+			if *updateFixtures {
+				if report.Len() == 0 {
+					if err := os.Remove(golden); !errors.Is(err, os.ErrNotExist) {
+						require.NoError(t, err)
+					}
+
+					return
+				}
+
+				require.NoError(t, os.WriteFile(golden, []byte(report.String()), 0o644))
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if !errors.Is(err, os.ErrNotExist) {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, string(want), report.String(), "schema drift in %s", file)
+		})
+	}
+}
+
+func TestWithStrictDecoding(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	var warnings []fppclient.DecodeWarning
+
+	c, err := fppclient.New(srv.URL, fppclient.WithStrictDecoding(func(w fppclient.DecodeWarning) {
+		warnings = append(warnings, w)
+	}))
+	require.NoError(t, err)
+
+	_, err = c.GetFPPDStatus(context.TODO())
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+
+	srv.UpdateStatus(func(status map[string]interface{}) {
+		status["brightness"] = 100
+		status["volume"] = "70"
+	})
+
+	_, err = c.GetFPPDStatus(context.TODO())
+	require.Error(t, err)
+
+	require.Equal(t, []fppclient.DecodeWarning{{
+		Method: "GET",
+		Path:   "/api/fppd/status",
+		Kind:   fppclient.WarningUnknownField,
+		Field:  "brightness",
+		Value:  "100",
+	}, {
+		Method: "GET",
+		Path:   "/api/fppd/status",
+		Kind:   fppclient.WarningTypeMismatch,
+		Field:  "volume",
+		Want:   "int",
+		Value:  `"70"`,
+	}}, warnings)
+
+	require.Equal(t, `GET /api/fppd/status: volume: type mismatch, want int, got "70"`, warnings[1].String())
+}
@@ -33,6 +33,24 @@ func (fi *Intish) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// A Stringish is a string that can be unmarshalled from a JSON field
+// that has either a string, number or boolean value.
+type Stringish string
+
+func (s *Stringish) UnmarshalJSON(b []byte) error {
+	if b[0] == '"' {
+		return json.Unmarshal(b, (*string)(s))
+	}
+
+	if string(b) == "null" {
+		*s = ""
+		return nil
+	}
+
+	*s = Stringish(b)
+	return nil
+}
+
 type FPPTime struct {
 	time.Time
 }
@@ -66,20 +84,46 @@ func (e *FPPTime) UnmarshalJSON(b []byte) error {
 type Models []Model
 
 type Model struct {
-	ChannelCount        int    `json:"ChannelCount"`
-	ChannelCountPerNode int    `json:"ChannelCountPerNode"`
-	Name                string `json:"Name"`
-	Orientation         string `json:"Orientation"`
-	StartChannel        int    `json:"StartChannel"`
-	StartCorner         string `json:"StartCorner"`
-	StrandsPerString    int    `json:"StrandsPerString"`
-	StringCount         int    `json:"StringCount"`
-	Type                string `json:"Type"`
-	AutoCreated         bool   `json:"autoCreated"`
-	EffectRunning       bool   `json:"effectRunning"`
-	Height              int    `json:"height"`
-	IsActive            int    `json:"isActive"`
-	Width               int    `json:"width"`
+	ChannelCount        int        `json:"ChannelCount"`
+	ChannelCountPerNode int        `json:"ChannelCountPerNode"`
+	Name                string     `json:"Name"`
+	Orientation         string     `json:"Orientation"`
+	StartChannel        int        `json:"StartChannel"`
+	StartCorner         string     `json:"StartCorner"`
+	StrandsPerString    int        `json:"StrandsPerString"`
+	StringCount         int        `json:"StringCount"`
+	Type                string     `json:"Type"`
+	AutoCreated         bool       `json:"autoCreated"`
+	EffectRunning       bool       `json:"effectRunning"`
+	Height              int        `json:"height"`
+	IsActive            ModelState `json:"isActive"`
+	Width               int        `json:"width"`
+}
+
+// ModelState is the overlay state of a model, which determines how its
+// pixels are combined with the running sequence.
+type ModelState int
+
+const (
+	ModelStateDisabled ModelState = iota
+	ModelStateEnabled
+	ModelStateTransparent
+	ModelStateTransparentRGB
+)
+
+func (s ModelState) String() string {
+	switch s {
+	case ModelStateDisabled:
+		return "Disabled"
+	case ModelStateEnabled:
+		return "Enabled"
+	case ModelStateTransparent:
+		return "Transparent"
+	case ModelStateTransparentRGB:
+		return "TransparentRGB"
+	}
+
+	return fmt.Sprintf("ModelState(%d)", int(s))
 }
 
 type ModelData struct {
@@ -89,7 +133,25 @@ type ModelData struct {
 	RLE           bool  `json:"rle"`
 }
 
+func (m ModelData) checkLocked(name string) error {
+	if m.IsLocked {
+		return fmt.Errorf("model %q: %w", name, ErrModelLocked)
+	}
+
+	return nil
+}
+
 type Fonts []string
+
+func (f Fonts) Contains(font string) bool {
+	for _, v := range f {
+		if v == font {
+			return true
+		}
+	}
+	return false
+}
+
 type Plugins []string
 
 func (p Plugins) Contains(plugin string) bool {
@@ -112,6 +174,11 @@ type fillPixelRequest struct {
 	RGB []int `json:"RGB"`
 }
 
+type modelDataRequest struct {
+	RLE  bool  `json:"rle"`
+	Data []int `json:"data"`
+}
+
 type ChannelOutputsObj struct {
 	ChannelOutputs `json:"channelOutputs"`
 }
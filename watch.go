@@ -0,0 +1,331 @@
+package fppclient
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// StatusEvent is implemented by every event sent by Client.Watch, use a type
+// switch to tell them apart.
+type StatusEvent interface {
+	EventTime() time.Time
+}
+
+// EventBase holds what is common to all status events.
+type EventBase struct {
+	// Time is when the status that raised the event was polled.
+	Time time.Time
+}
+
+func (e EventBase) EventTime() time.Time { return e.Time }
+
+// PlaylistStarted is sent when a playlist starts playing.
+type PlaylistStarted struct {
+	EventBase
+	Playlist string
+}
+
+// PlaylistStopped is sent when the running playlist stops, or is replaced
+// by another.
+type PlaylistStopped struct {
+	EventBase
+	Playlist string
+}
+
+// SequenceChanged is sent when the running sequence changes, Current is
+// empty when nothing is running.
+type SequenceChanged struct {
+	EventBase
+	Previous string
+	Current  string
+}
+
+// ItemAdvanced is sent when the running playlist moves to another item,
+// Index is one based.
+type ItemAdvanced struct {
+	EventBase
+	Playlist string
+	Previous int
+	Index    int
+	Count    int
+}
+
+// ModeChanged is sent when FPP changes mode, e.g. player to remote.
+type ModeChanged struct {
+	EventBase
+	Previous string
+	Current  string
+}
+
+// SchedulerNextChanged is sent when the next scheduled playlist changes,
+// Playlist is empty and StartTime zero when nothing is scheduled.
+type SchedulerNextChanged struct {
+	EventBase
+	Playlist  string
+	StartTime time.Time
+}
+
+// WarningRaised is sent when FPP reports a new warning.
+type WarningRaised struct {
+	EventBase
+	Warning string
+}
+
+// WarningCleared is sent when a warning is no longer reported.
+type WarningCleared struct {
+	EventBase
+	Warning string
+}
+
+// SensorThreshold is sent when a sensor watched with WithSensorThreshold
+// crosses its threshold, Above says in which direction.
+type SensorThreshold struct {
+	EventBase
+	Label     string
+	Value     float64
+	Threshold float64
+	Above     bool
+}
+
+// Disconnected is sent when polling the status fails, Watch then backs off
+// until it succeeds again.
+type Disconnected struct {
+	EventBase
+	Err error
+}
+
+// Reconnected is sent when the status can be polled again after a
+// Disconnected.
+type Reconnected struct {
+	EventBase
+	Downtime time.Duration
+}
+
+type watchConfig struct {
+	maxBackoff time.Duration
+	thresholds map[string]float64
+}
+
+type WatchOption func(w *watchConfig)
+
+// WithSensorThreshold sends SensorThreshold events when the labelled sensor
+// crosses threshold. Labels are matched ignoring a trailing colon, so "CPU"
+// matches FPP's "CPU: ".
+func WithSensorThreshold(label string, threshold float64) WatchOption {
+	return func(w *watchConfig) {
+		w.thresholds[sensorLabel(label)] = threshold
+	}
+}
+
+// WithMaxBackoff caps how long Watch waits between polls while FPP can't be
+// reached, the default is one minute.
+func WithMaxBackoff(d time.Duration) WatchOption {
+	return func(w *watchConfig) {
+		w.maxBackoff = d
+	}
+}
+
+func sensorLabel(label string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(label), ":"))
+}
+
+// Watch polls the status every interval and sends an event for each change
+// it sees. The first status is compared against an idle FPP with nothing
+// scheduled, so whatever is already running, warned about or scheduled is
+// announced.
+//
+// When a poll fails a Disconnected event is sent and the wait between polls
+// doubles, up to the maximum backoff, until a Reconnected event. The channel
+// is closed once ctx is done.
+func (c Client) Watch(ctx context.Context, interval time.Duration, opts ...WatchOption) <-chan StatusEvent {
+	cfg := watchConfig{
+		maxBackoff: time.Minute,
+		thresholds: map[string]float64{},
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.maxBackoff < interval {
+		cfg.maxBackoff = interval
+	}
+
+	events := make(chan StatusEvent, 16)
+
+	go func() {
+		defer close(events)
+
+		w := statusWatcher{cfg: cfg, above: map[string]bool{}}
+
+		var (
+			down  time.Time
+			delay time.Duration
+		)
+
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			status, err := c.GetFPPDStatus(ctx)
+			now := time.Now()
+
+			var batch []StatusEvent
+
+			switch {
+			case err != nil && ctx.Err() != nil:
+				return
+			case err != nil:
+				if down.IsZero() {
+					down = now
+					batch = append(batch, Disconnected{EventBase{now}, err})
+				}
+
+				if delay = delay * 2; delay == 0 {
+					delay = interval
+				} else if delay > cfg.maxBackoff {
+					delay = cfg.maxBackoff
+				}
+			default:
+				if !down.IsZero() {
+					batch = append(batch, Reconnected{EventBase{now}, now.Sub(down)})
+					down = time.Time{}
+				}
+
+				batch = append(batch, w.diff(status, now)...)
+				delay = 0
+			}
+
+			for _, e := range batch {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if delay > 0 {
+				timer.Reset(delay)
+			} else {
+				timer.Reset(interval)
+			}
+		}
+	}()
+
+	return events
+}
+
+type statusWatcher struct {
+	cfg   watchConfig
+	prev  FPPDStatus
+	above map[string]bool
+}
+
+// playingPlaylist returns the running playlist, if any.
+func playingPlaylist(s FPPDStatus) string {
+	if s.Status == 0 {
+		return ""
+	}
+
+	return s.CurrentPlaylist.Playlist
+}
+
+// nextScheduled returns the next scheduled playlist and when it starts, FPP
+// reports a placeholder name starting at 0 when there isn't one.
+func nextScheduled(s FPPDStatus) (string, time.Time) {
+	next := s.Scheduler.NextPlaylist
+	if next.PlaylistName == "" || next.ScheduledStartTime.IsZero() || next.ScheduledStartTime.Unix() == 0 {
+		return "", time.Time{}
+	}
+
+	return next.PlaylistName, next.ScheduledStartTime.Time
+}
+
+// diff returns the events between the previous status and s, in a stable
+// order, and remembers s for next time.
+func (w *statusWatcher) diff(s FPPDStatus, now time.Time) (events []StatusEvent) {
+	base := EventBase{now}
+	prev := w.prev
+	w.prev = s
+
+	prevPlaylist, playlist := playingPlaylist(prev), playingPlaylist(s)
+
+	if prevPlaylist != playlist {
+		if prevPlaylist != "" {
+			events = append(events, PlaylistStopped{base, prevPlaylist})
+		}
+
+		if playlist != "" {
+			events = append(events, PlaylistStarted{base, playlist})
+		}
+	}
+
+	if s.CurrentSequence != prev.CurrentSequence {
+		events = append(events, SequenceChanged{base, prev.CurrentSequence, s.CurrentSequence})
+	}
+
+	if playlist != "" && playlist == prevPlaylist && s.CurrentPlaylist.Index != prev.CurrentPlaylist.Index {
+		events = append(events, ItemAdvanced{
+			EventBase: base,
+			Playlist:  playlist,
+			Previous:  prev.CurrentPlaylist.Index,
+			Index:     s.CurrentPlaylist.Index,
+			Count:     s.CurrentPlaylist.Count,
+		})
+	}
+
+	if s.ModeName != prev.ModeName {
+		events = append(events, ModeChanged{base, prev.ModeName, s.ModeName})
+	}
+
+	next, start := nextScheduled(s)
+	prevNext, prevStart := nextScheduled(prev)
+	if next != prevNext || !start.Equal(prevStart) {
+		events = append(events, SchedulerNextChanged{base, next, start})
+	}
+
+	for _, warning := range s.Warnings {
+		if !containsString(prev.Warnings, warning) {
+			events = append(events, WarningRaised{base, warning})
+		}
+	}
+
+	for _, warning := range prev.Warnings {
+		if !containsString(s.Warnings, warning) {
+			events = append(events, WarningCleared{base, warning})
+		}
+	}
+
+	for _, sensor := range s.Sensors {
+		label := sensorLabel(sensor.Label)
+
+		threshold, ok := w.cfg.thresholds[label]
+		if !ok {
+			continue
+		}
+
+		if above := sensor.Value > threshold; above != w.above[label] {
+			w.above[label] = above
+			events = append(events, SensorThreshold{base, label, sensor.Value, threshold, above})
+		}
+	}
+
+	return events
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package fppclient_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/freman/fppclient"
+	"github.com/freman/fppclient/fpptest"
+)
+
+// nextEvent waits for an event, ignoring the time it was raised at.
+func nextEvent(t *testing.T, events <-chan fppclient.StatusEvent) fppclient.StatusEvent {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		require.True(t, ok, "events closed")
+		require.False(t, e.EventTime().IsZero())
+
+		v := reflect.New(reflect.TypeOf(e)).Elem()
+		v.Set(reflect.ValueOf(e))
+		v.FieldByName("EventBase").Set(reflect.Zero(reflect.TypeOf(fppclient.EventBase{})))
+
+		return v.Interface().(fppclient.StatusEvent)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	srv := fpptest.NewServer()
+	defer srv.Close()
+
+	srv.AddPlaylist(fppclient.Playlist{
+		Name: "Show",
+		MainPlaylist: fppclient.PlaylistEntries{
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "one.fseq"},
+			&fppclient.SequenceEntry{EntryBase: fppclient.EntryBase{Type: "sequence", Enabled: 1}, SequenceName: "two.fseq"},
+		},
+	})
+
+	srv.UpdateStatus(func(status map[string]interface{}) {
+		status["warnings"] = []string{"Audio device busy"}
+		status["sensors"] = []map[string]interface{}{{"label": "CPU: ", "value": 45.0}}
+	})
+
+	c, err := fppclient.New(srv.URL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := c.Watch(ctx, 5*time.Millisecond,
+		fppclient.WithSensorThreshold("CPU", 60),
+		fppclient.WithMaxBackoff(20*time.Millisecond),
+	)
+
+	// The initial state.
+	require.Equal(t, fppclient.ModeChanged{Current: "player"}, nextEvent(t, events))
+	require.Equal(t, fppclient.WarningRaised{Warning: "Audio device busy"}, nextEvent(t, events))
+
+	require.NoError(t, c.StartPlaylist(ctx, "Show", fppclient.StartPlaylistOptions{}))
+
+	require.Equal(t, fppclient.PlaylistStarted{Playlist: "Show"}, nextEvent(t, events))
+	require.Equal(t, fppclient.SequenceChanged{Current: "one.fseq"}, nextEvent(t, events))
+
+	require.NoError(t, c.NextPlaylistItem(ctx))
+
+	require.Equal(t, fppclient.SequenceChanged{Previous: "one.fseq", Current: "two.fseq"}, nextEvent(t, events))
+	require.Equal(t, fppclient.ItemAdvanced{Playlist: "Show", Previous: 1, Index: 2, Count: 2}, nextEvent(t, events))
+
+	srv.UpdateStatus(func(status map[string]interface{}) {
+		status["warnings"] = []string{}
+		status["sensors"] = []map[string]interface{}{{"label": "CPU: ", "value": 72.5}}
+	})
+
+	require.Equal(t, fppclient.WarningCleared{Warning: "Audio device busy"}, nextEvent(t, events))
+	require.Equal(t, fppclient.SensorThreshold{Label: "CPU", Value: 72.5, Threshold: 60, Above: true}, nextEvent(t, events))
+
+	start := time.Date(2026, time.December, 24, 18, 0, 0, 0, time.UTC)
+
+	srv.UpdateStatus(func(status map[string]interface{}) {
+		status["scheduler"] = map[string]interface{}{
+			"enabled": 1,
+			"nextPlaylist": map[string]interface{}{
+				"playlistName":       "Show",
+				"scheduledStartTime": start.Unix(),
+			},
+			"status": "idle",
+		}
+	})
+
+	scheduled, ok := nextEvent(t, events).(fppclient.SchedulerNextChanged)
+	require.True(t, ok)
+	require.Equal(t, "Show", scheduled.Playlist)
+	require.True(t, start.Equal(scheduled.StartTime))
+
+	srv.Fail(fpptest.Failure{Path: "/api/fppd/status"})
+
+	disconnected, ok := nextEvent(t, events).(fppclient.Disconnected)
+	require.True(t, ok)
+	require.ErrorIs(t, disconnected.Err, fppclient.ErrServer)
+
+	require.NoError(t, c.StopPlaylist(ctx))
+	srv.ClearFailures()
+
+	reconnected, ok := nextEvent(t, events).(fppclient.Reconnected)
+	require.True(t, ok)
+	require.Greater(t, reconnected.Downtime, time.Duration(0))
+
+	// Changes made while disconnected are reported once back.
+	require.Equal(t, fppclient.PlaylistStopped{Playlist: "Show"}, nextEvent(t, events))
+	require.Equal(t, fppclient.SequenceChanged{Previous: "two.fseq"}, nextEvent(t, events))
+
+	cancel()
+
+	for range events {
+	}
+}